@@ -0,0 +1,65 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAlerts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAlertsRead,
+		Schema: map[string]*schema.Schema{
+			"alerts": createComputedList(map[string]*schema.Schema{
+				"alert_code":            &computedString,
+				"alert_message":         &computedString,
+				"category":              &computedString,
+				"last_modified_time":    &computedString,
+				"related_resource_arns": &computedStringList,
+				"resource_arn":          &computedString,
+			}),
+			"region":       &optionalComputedRegion,
+			"resource_arn": &requiredString,
+		},
+	}
+}
+
+func dataSourceAlertsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	if err := setRegion(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+	resourceArn := d.Get("resource_arn").(string)
+
+	var alerts []interface{}
+	err := client.ListAlertsPagesWithContext(ctx, &mediatailor.ListAlertsInput{ResourceArn: aws.String(resourceArn)}, func(page *mediatailor.ListAlertsOutput, lastPage bool) bool {
+		for _, a := range page.Items {
+			alerts = append(alerts, flattenAlert(a))
+		}
+		return true
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while listing the alerts: %v", err))
+	}
+
+	d.SetId(resourceArn)
+
+	if err := d.Set("alerts", alerts); err != nil {
+		return diag.FromErr(fmt.Errorf("error while setting the alerts: %v", err))
+	}
+	return nil
+}
+
+func flattenAlert(a *mediatailor.Alert) map[string]interface{} {
+	return map[string]interface{}{
+		"alert_code":            aws.StringValue(a.AlertCode),
+		"alert_message":         aws.StringValue(a.AlertMessage),
+		"category":              aws.StringValue(a.Category),
+		"last_modified_time":    flattenTime(a.LastModifiedTime),
+		"related_resource_arns": aws.StringValueSlice(a.RelatedResourceArns),
+		"resource_arn":          aws.StringValue(a.ResourceArn),
+	}
+}
@@ -0,0 +1,39 @@
+package awsmt
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccAlertsDataSourceBasic(t *testing.T) {
+	dataSourceName := "data.awsmt_alerts.test"
+	rName := "alerts_data_source_test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAlertsDataSourceBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", "awsmt_source_location.test", "arn"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "resource_arn", "awsmt_source_location.test", "arn"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "alerts.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAlertsDataSourceBasic(rName string) string {
+	return fmt.Sprintf(`
+resource "awsmt_source_location" "test" {
+  name                   = "%[1]s"
+  http_configuration_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/test-img.jpeg"
+}
+
+data "awsmt_alerts" "test" {
+  resource_arn = awsmt_source_location.test.arn
+}
+`, rName)
+}
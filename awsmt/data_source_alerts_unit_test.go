@@ -0,0 +1,38 @@
+package awsmt
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFlattenAlert(t *testing.T) {
+	// arrange
+	lastModifiedTime := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	input := mediatailor.Alert{
+		AlertCode:           aws.String("SOURCE_URL_UNREACHABLE"),
+		AlertMessage:        aws.String("message"),
+		Category:            aws.String("PLAYBACK_WARNING"),
+		LastModifiedTime:    &lastModifiedTime,
+		RelatedResourceArns: aws.StringSlice([]string{"arn1", "arn2"}),
+		ResourceArn:         aws.String("arn"),
+	}
+	expected := map[string]interface{}{
+		"alert_code":            "SOURCE_URL_UNREACHABLE",
+		"alert_message":         "message",
+		"category":              "PLAYBACK_WARNING",
+		"last_modified_time":    lastModifiedTime.String(),
+		"related_resource_arns": []string{"arn1", "arn2"},
+		"resource_arn":          "arn",
+	}
+
+	// act
+	output := flattenAlert(&input)
+
+	// assert
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("expected %v, got %v", expected, output)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/aws/aws-sdk-go/service/mediatailor"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"strings"
 )
 
 func dataSourceChannel() *schema.Resource {
@@ -23,6 +22,13 @@ func dataSourceChannel() *schema.Resource {
 				"vod_source_name":      &computedString,
 			}),
 			"last_modified_time": &computedString,
+			"log_configuration": createComputedList(map[string]*schema.Schema{
+				"log_types": {
+					Type:     schema.TypeSet,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			}),
 			"outputs": createComputedList(map[string]*schema.Schema{
 				"dash_manifest_windows_seconds":             &computedInt,
 				"dash_min_buffer_time_seconds":              &computedInt,
@@ -35,35 +41,37 @@ func dataSourceChannel() *schema.Resource {
 			}),
 			"playback_mode": &computedString,
 			"policy":        &computedString,
+			"region":        &optionalComputedRegion,
 			"tags":          &computedTags,
 			"tier":          &computedString,
 		},
 	}
 }
 
-func dataSourceChannelRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mediatailor.MediaTailor)
+func dataSourceChannelRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := getClient(d, m)
+	if err := setRegion(d, m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	name := d.Get("name").(string)
-	res, err := client.DescribeChannel(&mediatailor.DescribeChannelInput{ChannelName: &name})
+	res, err := client.DescribeChannelWithContext(ctx, &mediatailor.DescribeChannelInput{ChannelName: &name})
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while retrieving the channel: %w", err))
 	}
 
-	policy, err := client.GetChannelPolicy(&mediatailor.GetChannelPolicyInput{ChannelName: aws.String(name)})
-	if err != nil && !strings.Contains(err.Error(), "NotFound") {
+	policy, err := client.GetChannelPolicyWithContext(ctx, &mediatailor.GetChannelPolicyInput{ChannelName: aws.String(name)})
+	if err != nil && !isNotFound(err) {
 		return diag.FromErr(fmt.Errorf("error while getting the channel policy: %v", err))
 	}
 	if err := setChannelPolicy(policy, d); err != nil {
-		diag.FromErr(err)
+		return diag.FromErr(err)
 	}
 
 	d.SetId(aws.StringValue(res.ChannelName))
 
-	err = setChannel(res, d)
-	if err != nil {
-		diag.FromErr(err)
+	if diags := setChannel(res, d); diags.HasError() {
+		return diags
 	}
-
-	return nil
+	return diagnosticsFromErrors([]error{setAttribute(d, "tags", res.Tags)})
 }
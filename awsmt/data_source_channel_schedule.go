@@ -0,0 +1,97 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"strconv"
+)
+
+func dataSourceChannelSchedule() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceChannelScheduleRead,
+		Schema: map[string]*schema.Schema{
+			"channel_name": &requiredString,
+			"duration_minutes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"region": &optionalComputedRegion,
+			"schedule_entries": createComputedList(map[string]*schema.Schema{
+				"approximate_duration_seconds": &computedInt,
+				"approximate_start_time":       &computedString,
+				"arn":                          &computedString,
+				"live_source_name":             &computedString,
+				"program_name":                 &computedString,
+				"schedule_ad_breaks": createComputedList(map[string]*schema.Schema{
+					"approximate_duration_seconds": &computedInt,
+					"approximate_start_time":       &computedString,
+					"source_location_name":         &computedString,
+					"vod_source_name":              &computedString,
+				}),
+				"schedule_entry_type":  &computedString,
+				"source_location_name": &computedString,
+				"vod_source_name":      &computedString,
+			}),
+		},
+	}
+}
+
+func dataSourceChannelScheduleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	if err := setRegion(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+	channelName := d.Get("channel_name").(string)
+
+	input := mediatailor.GetChannelScheduleInput{ChannelName: aws.String(channelName)}
+	if v, ok := d.GetOk("duration_minutes"); ok {
+		input.DurationMinutes = aws.String(strconv.Itoa(v.(int)))
+	}
+
+	var entries []interface{}
+	err := client.GetChannelSchedulePagesWithContext(ctx, &input, func(page *mediatailor.GetChannelScheduleOutput, lastPage bool) bool {
+		for _, e := range page.Items {
+			entries = append(entries, flattenScheduleEntry(e))
+		}
+		return true
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while retrieving the channel schedule: %v", err))
+	}
+
+	d.SetId(channelName)
+
+	if err := d.Set("schedule_entries", entries); err != nil {
+		return diag.FromErr(fmt.Errorf("error while setting the schedule entries: %v", err))
+	}
+	return nil
+}
+
+func flattenScheduleEntry(e *mediatailor.ScheduleEntry) map[string]interface{} {
+	var adBreaks []interface{}
+	for _, a := range e.ScheduleAdBreaks {
+		adBreaks = append(adBreaks, map[string]interface{}{
+			"approximate_duration_seconds": aws.Int64Value(a.ApproximateDurationSeconds),
+			"approximate_start_time":       flattenTime(a.ApproximateStartTime),
+			"source_location_name":         aws.StringValue(a.SourceLocationName),
+			"vod_source_name":              aws.StringValue(a.VodSourceName),
+		})
+	}
+	return map[string]interface{}{
+		"approximate_duration_seconds": aws.Int64Value(e.ApproximateDurationSeconds),
+		"approximate_start_time":       flattenTime(e.ApproximateStartTime),
+		"arn":                          aws.StringValue(e.Arn),
+		"live_source_name":             aws.StringValue(e.LiveSourceName),
+		"program_name":                 aws.StringValue(e.ProgramName),
+		"schedule_ad_breaks":           adBreaks,
+		"schedule_entry_type":          aws.StringValue(e.ScheduleEntryType),
+		"source_location_name":         aws.StringValue(e.SourceLocationName),
+		"vod_source_name":              aws.StringValue(e.VodSourceName),
+	}
+}
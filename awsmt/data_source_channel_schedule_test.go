@@ -0,0 +1,60 @@
+package awsmt
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestAccChannelScheduleDataSourceBasic(t *testing.T) {
+	dataSourceName := "data.awsmt_channel_schedule.test"
+	sourceLocationName := "channel_schedule_source_location"
+	vodSourceName := "channel_schedule_vod_source"
+	channelName := "channel_schedule_channel"
+	programName := "channel_schedule_program"
+	startTime := time.Now().Add(10 * time.Minute).UnixMilli()
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccChannelScheduleDataSourceBasic(sourceLocationName, vodSourceName, channelName, programName, startTime),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", channelName),
+					resource.TestCheckResourceAttr(dataSourceName, "schedule_entries.0.program_name", programName),
+					resource.TestCheckResourceAttr(dataSourceName, "schedule_entries.0.source_location_name", sourceLocationName),
+					resource.TestCheckResourceAttr(dataSourceName, "schedule_entries.0.vod_source_name", vodSourceName),
+					resource.TestMatchResourceAttr(dataSourceName, "schedule_entries.0.arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:program\/.*$`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccChannelScheduleDataSourceBasic(sourceLocationName, vodSourceName, channelName, programName string, startTime int64) string {
+	return testAccProgramConfig(sourceLocationName, vodSourceName, channelName, programName, 0, startTime) + `
+data "awsmt_channel_schedule" "test" {
+  channel_name     = awsmt_program.test.channel_name
+  duration_minutes = 60
+}
+`
+}
+
+func TestAccChannelScheduleDataSourceValidateDuration(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "awsmt_channel_schedule" "test" {
+  channel_name     = "channel_schedule_validation"
+  duration_minutes = 0
+}
+`,
+				ExpectError: regexp.MustCompile(`expected duration_minutes to be at least \(1\)`),
+			},
+		},
+	})
+}
@@ -0,0 +1,54 @@
+package awsmt
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFlattenScheduleEntry(t *testing.T) {
+	// arrange
+	startTime := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	input := mediatailor.ScheduleEntry{
+		ApproximateDurationSeconds: aws.Int64(60),
+		ApproximateStartTime:       &startTime,
+		Arn:                        aws.String("arn"),
+		ChannelName:                aws.String("channel"),
+		ProgramName:                aws.String("program"),
+		ScheduleAdBreaks: []*mediatailor.ScheduleAdBreak{{
+			ApproximateDurationSeconds: aws.Int64(10),
+			ApproximateStartTime:       &startTime,
+			SourceLocationName:         aws.String("source_location"),
+			VodSourceName:              aws.String("slate"),
+		}},
+		ScheduleEntryType:  aws.String("PROGRAM"),
+		SourceLocationName: aws.String("source_location"),
+		VodSourceName:      aws.String("vod_source"),
+	}
+	expected := map[string]interface{}{
+		"approximate_duration_seconds": int64(60),
+		"approximate_start_time":       startTime.String(),
+		"arn":                          "arn",
+		"live_source_name":             "",
+		"program_name":                 "program",
+		"schedule_ad_breaks": []interface{}{map[string]interface{}{
+			"approximate_duration_seconds": int64(10),
+			"approximate_start_time":       startTime.String(),
+			"source_location_name":         "source_location",
+			"vod_source_name":              "slate",
+		}},
+		"schedule_entry_type":  "PROGRAM",
+		"source_location_name": "source_location",
+		"vod_source_name":      "vod_source",
+	}
+
+	// act
+	output := flattenScheduleEntry(&input)
+
+	// assert
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("expected %v, got %v", expected, output)
+	}
+}
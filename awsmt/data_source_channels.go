@@ -0,0 +1,78 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceChannels() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceChannelsRead,
+		Schema: map[string]*schema.Schema{
+			"arns": &computedStringList,
+			"channels": createComputedList(map[string]*schema.Schema{
+				"arn":                &computedString,
+				"channel_state":      &computedString,
+				"creation_time":      &computedString,
+				"last_modified_time": &computedString,
+				"name":               &computedString,
+				"playback_mode":      &computedString,
+				"tags":               &computedTags,
+				"tier":               &computedString,
+			}),
+			"name_regex": &optionalNameRegex,
+			"names":      &computedStringList,
+			"region":     &optionalComputedRegion,
+			"tags":       &optionalTags,
+		},
+	}
+}
+
+func dataSourceChannelsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	if err := setRegion(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := getListFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var arns, names []string
+	var channels []map[string]interface{}
+	err = client.ListChannelsPagesWithContext(ctx, &mediatailor.ListChannelsInput{}, func(page *mediatailor.ListChannelsOutput, lastPage bool) bool {
+		for _, c := range page.Items {
+			if !filter.matches(c.ChannelName, c.Tags) {
+				continue
+			}
+			arns = append(arns, aws.StringValue(c.Arn))
+			names = append(names, aws.StringValue(c.ChannelName))
+			channels = append(channels, map[string]interface{}{
+				"arn":                c.Arn,
+				"channel_state":      c.ChannelState,
+				"creation_time":      flattenTime(c.CreationTime),
+				"last_modified_time": flattenTime(c.LastModifiedTime),
+				"name":               c.ChannelName,
+				"playback_mode":      c.PlaybackMode,
+				"tags":               c.Tags,
+				"tier":               c.Tier,
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while listing the channels: %v", err))
+	}
+
+	d.SetId(d.Get("region").(string))
+
+	if err := setListValues(d, map[string]interface{}{"arns": arns, "names": names, "channels": channels}); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package awsmt
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccChannelsDataSourceBasic(t *testing.T) {
+	dataSourceName := "data.awsmt_channels.test"
+	rName := "channels_data_source_test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccChannelsDataSourceBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "names.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "names.0", rName),
+					resource.TestMatchResourceAttr(dataSourceName, "arns.0", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:channel\/.*$`)),
+					resource.TestCheckResourceAttr(dataSourceName, "channels.0.name", rName),
+					resource.TestCheckResourceAttr(dataSourceName, "channels.0.playback_mode", "LOOP"),
+					resource.TestCheckResourceAttr(dataSourceName, "channels.0.tier", "BASIC"),
+					resource.TestCheckResourceAttr(dataSourceName, "channels.0.tags.Environment", "dev"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccChannelsDataSourceNoMatch(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "awsmt_channels" "test" {
+  name_regex = "^this_channel_does_not_exist$"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.awsmt_channels.test", "names.#", "0"),
+					resource.TestCheckResourceAttr("data.awsmt_channels.test", "channels.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccChannelsDataSourceBasic(rName string) string {
+	return fmt.Sprintf(`
+resource "awsmt_channel" "test" {
+  name = "%[1]s"
+  outputs {
+    manifest_name                = "default"
+    source_group                 = "default"
+    hls_manifest_windows_seconds = 30
+  }
+  playback_mode = "LOOP"
+  tier          = "BASIC"
+  tags          = { "Environment" : "dev" }
+}
+
+data "awsmt_channels" "test" {
+  name_regex = "^${awsmt_channel.test.name}$"
+  tags       = { "Environment" : "dev" }
+}
+`, rName)
+}
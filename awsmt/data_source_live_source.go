@@ -22,29 +22,32 @@ func dataSourceLiveSource() *schema.Resource {
 			}),
 			"last_modified_time":   &computedString,
 			"name":                 &requiredString,
+			"region":               &optionalComputedRegion,
 			"source_location_name": &requiredString,
 			"tags":                 &computedTags,
 		},
 	}
 }
 
-func dataSourceLiveSourceRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
+func dataSourceLiveSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	if err := setRegion(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
 	resourceName := d.Get("name").(string)
 	sourceLocationName := d.Get("source_location_name").(string)
 
 	input := &mediatailor.DescribeLiveSourceInput{SourceLocationName: &(sourceLocationName), LiveSourceName: aws.String(resourceName)}
 
-	res, err := client.DescribeLiveSource(input)
+	res, err := client.DescribeLiveSourceWithContext(ctx, input)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while reading the live source: %v", err))
 	}
 
 	d.SetId(fmt.Sprintf("%q/%q", *res.SourceLocationName, *res.LiveSourceName))
 
-	if err = setLiveSource(res, d); err != nil {
-		return diag.FromErr(err)
+	if diags := setLiveSource(res, d); diags.HasError() {
+		return diags
 	}
-
-	return nil
+	return diagnosticsFromErrors([]error{setAttribute(d, "tags", res.Tags)})
 }
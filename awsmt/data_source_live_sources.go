@@ -0,0 +1,76 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceLiveSources() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceLiveSourcesRead,
+		Schema: map[string]*schema.Schema{
+			"arns":                 &computedStringList,
+			"name_regex":           &optionalNameRegex,
+			"names":                &computedStringList,
+			"region":               &optionalComputedRegion,
+			"source_location_name": &requiredString,
+			"tags":                 &optionalTags,
+			"live_sources": createComputedList(map[string]*schema.Schema{
+				"arn":                  &computedString,
+				"creation_time":        &computedString,
+				"last_modified_time":   &computedString,
+				"name":                 &computedString,
+				"source_location_name": &computedString,
+				"tags":                 &computedTags,
+			}),
+		},
+	}
+}
+
+func dataSourceLiveSourcesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	if err := setRegion(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+	sourceLocationName := d.Get("source_location_name").(string)
+
+	filter, err := getListFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var arns, names []string
+	var liveSources []map[string]interface{}
+	err = client.ListLiveSourcesPagesWithContext(ctx, &mediatailor.ListLiveSourcesInput{SourceLocationName: aws.String(sourceLocationName)}, func(page *mediatailor.ListLiveSourcesOutput, lastPage bool) bool {
+		for _, v := range page.Items {
+			if !filter.matches(v.LiveSourceName, v.Tags) {
+				continue
+			}
+			arns = append(arns, aws.StringValue(v.Arn))
+			names = append(names, aws.StringValue(v.LiveSourceName))
+			liveSources = append(liveSources, map[string]interface{}{
+				"arn":                  v.Arn,
+				"creation_time":        flattenTime(v.CreationTime),
+				"last_modified_time":   flattenTime(v.LastModifiedTime),
+				"name":                 v.LiveSourceName,
+				"source_location_name": v.SourceLocationName,
+				"tags":                 v.Tags,
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while listing the live sources: %v", err))
+	}
+
+	d.SetId(sourceLocationName)
+
+	if err := setListValues(d, map[string]interface{}{"arns": arns, "names": names, "live_sources": liveSources}); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
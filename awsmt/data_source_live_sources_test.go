@@ -0,0 +1,54 @@
+package awsmt
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccLiveSourcesDataSourceBasic(t *testing.T) {
+	dataSourceName := "data.awsmt_live_sources.test"
+	sourceLocationName := "live_sources_data_source_location"
+	rName := "live_sources_data_source_test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLiveSourcesDataSourceBasic(sourceLocationName, rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", sourceLocationName),
+					resource.TestCheckResourceAttr(dataSourceName, "names.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "names.0", rName),
+					resource.TestMatchResourceAttr(dataSourceName, "arns.0", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:liveSource\/.*$`)),
+					resource.TestCheckResourceAttr(dataSourceName, "live_sources.0.source_location_name", sourceLocationName),
+				),
+			},
+		},
+	})
+}
+
+func testAccLiveSourcesDataSourceBasic(sourceLocationName, rName string) string {
+	return fmt.Sprintf(`
+resource "awsmt_source_location" "test" {
+  name                   = "%[1]s"
+  http_configuration_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/test-img.jpeg"
+}
+
+resource "awsmt_live_source" "test" {
+  http_package_configurations {
+    path         = "/"
+    source_group = "default"
+    type         = "HLS"
+  }
+  source_location_name = awsmt_source_location.test.name
+  name                 = "%[2]s"
+}
+
+data "awsmt_live_sources" "test" {
+  source_location_name = awsmt_source_location.test.name
+  name_regex           = "^${awsmt_live_source.test.name}$"
+}
+`, sourceLocationName, rName)
+}
@@ -2,7 +2,7 @@ package awsmt
 
 import (
 	"context"
-	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -61,6 +61,7 @@ func dataSourcePlaybackConfiguration() *schema.Resource {
 			"personalization_threshold_seconds":      &computedInt,
 			"playback_configuration_arn":             &computedString,
 			"playback_endpoint_prefix":               &computedString,
+			"region":                                 &optionalComputedRegion,
 			"session_initialization_endpoint_prefix": &computedString,
 			"slate_ad_url":                           &computedString,
 			"tags":                                   &computedTags,
@@ -70,19 +71,20 @@ func dataSourcePlaybackConfiguration() *schema.Resource {
 	}
 }
 
-func dataSourcePlaybackConfigurationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mediatailor.MediaTailor)
-	var diags diag.Diagnostics
-
+func dataSourcePlaybackConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := getClient(d, m)
+	if err := setRegion(d, m); err != nil {
+		return diag.FromErr(err)
+	}
 	name := d.Get("name").(string)
 
-	res, err := getSinglePlaybackConfiguration(client, name)
+	res, err := getSinglePlaybackConfiguration(ctx, client, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	output := flattenPlaybackConfiguration(res)
-	returnPlaybackConfiguration(d, output, diags)
-	d.SetId(*res.PlaybackConfigurationArn)
+	d.SetId(aws.StringValue(res.PlaybackConfigurationArn))
 
-	return diags
+	output := flattenPlaybackConfiguration(res)
+	output["tags"] = res.Tags
+	return returnPlaybackConfiguration(d, output)
 }
@@ -23,6 +23,9 @@ func TestAccPlaybackConfigurationDataSourceBasic(t *testing.T) {
 }
 
 func testAccPreCheck(t *testing.T) {
+	if isFakeBackend() {
+		return
+	}
 	if a, b, c := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"), os.Getenv("AWS_PROFILE"); (a == "" || b == "") && c == "" {
 		t.Fatal("Either AWS_PROFILE or both AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set for acceptance tests")
 	}
@@ -0,0 +1,76 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePlaybackConfigurations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePlaybackConfigurationsRead,
+		Schema: map[string]*schema.Schema{
+			"arns":       &computedStringList,
+			"name_regex": &optionalNameRegex,
+			"names":      &computedStringList,
+			"playback_configurations": createComputedList(map[string]*schema.Schema{
+				"ad_decision_server_url":                 &computedString,
+				"arn":                                    &computedString,
+				"name":                                   &computedString,
+				"playback_endpoint_prefix":               &computedString,
+				"session_initialization_endpoint_prefix": &computedString,
+				"tags":                                   &computedTags,
+				"video_content_source_url":               &computedString,
+			}),
+			"region": &optionalComputedRegion,
+			"tags":   &optionalTags,
+		},
+	}
+}
+
+func dataSourcePlaybackConfigurationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	if err := setRegion(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := getListFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var arns, names []string
+	var configurations []map[string]interface{}
+	err = client.ListPlaybackConfigurationsPagesWithContext(ctx, &mediatailor.ListPlaybackConfigurationsInput{}, func(page *mediatailor.ListPlaybackConfigurationsOutput, lastPage bool) bool {
+		for _, c := range page.Items {
+			if !filter.matches(c.Name, c.Tags) {
+				continue
+			}
+			arns = append(arns, aws.StringValue(c.PlaybackConfigurationArn))
+			names = append(names, aws.StringValue(c.Name))
+			configurations = append(configurations, map[string]interface{}{
+				"ad_decision_server_url":                 c.AdDecisionServerUrl,
+				"arn":                                    c.PlaybackConfigurationArn,
+				"name":                                   c.Name,
+				"playback_endpoint_prefix":               c.PlaybackEndpointPrefix,
+				"session_initialization_endpoint_prefix": c.SessionInitializationEndpointPrefix,
+				"tags":                                   c.Tags,
+				"video_content_source_url":               c.VideoContentSourceUrl,
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while listing the playback configurations: %v", err))
+	}
+
+	d.SetId(d.Get("region").(string))
+
+	if err := setListValues(d, map[string]interface{}{"arns": arns, "names": names, "playback_configurations": configurations}); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
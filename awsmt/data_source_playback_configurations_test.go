@@ -0,0 +1,47 @@
+package awsmt
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccPlaybackConfigurationsDataSourceBasic(t *testing.T) {
+	dataSourceName := "data.awsmt_playback_configurations.test"
+	rName := "playback_configurations_data_source_test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPlaybackConfigurationsDataSourceBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "names.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "names.0", rName),
+					resource.TestMatchResourceAttr(dataSourceName, "arns.0", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:playbackConfiguration\/.*$`)),
+					resource.TestCheckResourceAttr(dataSourceName, "playback_configurations.0.ad_decision_server_url", "https://exampleurl.com/"),
+					resource.TestCheckResourceAttr(dataSourceName, "playback_configurations.0.video_content_source_url", "https://exampleurl.com"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPlaybackConfigurationsDataSourceBasic(rName string) string {
+	return fmt.Sprintf(`
+resource "awsmt_playback_configuration" "test" {
+  ad_decision_server_url = "https://exampleurl.com/"
+  name                   = "%[1]s"
+  dash_configuration {
+    mpd_location         = "EMT_DEFAULT"
+    origin_manifest_type = "MULTI_PERIOD"
+  }
+  video_content_source_url = "https://exampleurl.com"
+}
+
+data "awsmt_playback_configurations" "test" {
+  name_regex = "^${awsmt_playback_configuration.test.name}$"
+}
+`, rName)
+}
@@ -31,6 +31,7 @@ func dataSourceSourceLocation() *schema.Resource {
 			"default_segment_delivery_configuration_url": &computedString,
 			"http_configuration_url":                     &computedString,
 			"last_modified_time":                         &computedString,
+			"region":                                     &optionalComputedRegion,
 			"segment_delivery_configurations": createComputedList(map[string]*schema.Schema{
 				"base_url": &computedString,
 				"name":     &computedString,
@@ -41,23 +42,25 @@ func dataSourceSourceLocation() *schema.Resource {
 	}
 }
 
-func dataSourceSourceLocationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mediatailor.MediaTailor)
+func dataSourceSourceLocationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := getClient(d, m)
+	if err := setRegion(d, m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	name := d.Get("name").(string)
 	if name == "" {
 		return diag.Errorf("`name` parameter required")
 	}
-	res, err := client.DescribeSourceLocation(&mediatailor.DescribeSourceLocationInput{SourceLocationName: &name})
+	res, err := client.DescribeSourceLocationWithContext(ctx, &mediatailor.DescribeSourceLocationInput{SourceLocationName: &name})
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while retrieving the source location: %w", err))
 	}
 
 	d.SetId(aws.StringValue(res.SourceLocationName))
 
-	err = setSourceLocation(res, d)
-	if err != nil {
-		diag.FromErr(err)
+	if diags := setSourceLocation(res, d); diags.HasError() {
+		return diags
 	}
-	return nil
+	return diagnosticsFromErrors([]error{setAttribute(d, "tags", res.Tags)})
 }
@@ -0,0 +1,77 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSourceLocations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSourceLocationsRead,
+		Schema: map[string]*schema.Schema{
+			"arns":       &computedStringList,
+			"name_regex": &optionalNameRegex,
+			"names":      &computedStringList,
+			"region":     &optionalComputedRegion,
+			"source_locations": createComputedList(map[string]*schema.Schema{
+				"arn":                    &computedString,
+				"creation_time":          &computedString,
+				"http_configuration_url": &computedString,
+				"last_modified_time":     &computedString,
+				"name":                   &computedString,
+				"tags":                   &computedTags,
+			}),
+			"tags": &optionalTags,
+		},
+	}
+}
+
+func dataSourceSourceLocationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	if err := setRegion(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := getListFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var arns, names []string
+	var sourceLocations []map[string]interface{}
+	err = client.ListSourceLocationsPagesWithContext(ctx, &mediatailor.ListSourceLocationsInput{}, func(page *mediatailor.ListSourceLocationsOutput, lastPage bool) bool {
+		for _, s := range page.Items {
+			if !filter.matches(s.SourceLocationName, s.Tags) {
+				continue
+			}
+			arns = append(arns, aws.StringValue(s.Arn))
+			names = append(names, aws.StringValue(s.SourceLocationName))
+			temp := map[string]interface{}{
+				"arn":                s.Arn,
+				"creation_time":      flattenTime(s.CreationTime),
+				"last_modified_time": flattenTime(s.LastModifiedTime),
+				"name":               s.SourceLocationName,
+				"tags":               s.Tags,
+			}
+			if s.HttpConfiguration != nil {
+				temp["http_configuration_url"] = s.HttpConfiguration.BaseUrl
+			}
+			sourceLocations = append(sourceLocations, temp)
+		}
+		return true
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while listing the source locations: %v", err))
+	}
+
+	d.SetId(d.Get("region").(string))
+
+	if err := setListValues(d, map[string]interface{}{"arns": arns, "names": names, "source_locations": sourceLocations}); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
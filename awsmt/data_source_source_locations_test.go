@@ -0,0 +1,42 @@
+package awsmt
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccSourceLocationsDataSourceBasic(t *testing.T) {
+	dataSourceName := "data.awsmt_source_locations.test"
+	rName := "source_locations_data_source_test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourceLocationsDataSourceBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "names.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "names.0", rName),
+					resource.TestMatchResourceAttr(dataSourceName, "arns.0", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:sourceLocation\/.*$`)),
+					resource.TestCheckResourceAttr(dataSourceName, "source_locations.0.http_configuration_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/test-img.jpeg"),
+					resource.TestMatchResourceAttr(dataSourceName, "source_locations.0.creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccSourceLocationsDataSourceBasic(rName string) string {
+	return fmt.Sprintf(`
+resource "awsmt_source_location" "test" {
+  name                   = "%[1]s"
+  http_configuration_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/test-img.jpeg"
+}
+
+data "awsmt_source_locations" "test" {
+  name_regex = "^${awsmt_source_location.test.name}$"
+}
+`, rName)
+}
@@ -21,6 +21,7 @@ func dataSourceVodSource() *schema.Resource {
 				"type":         &computedString,
 			}),
 			"last_modified_time":   &computedString,
+			"region":               &optionalComputedRegion,
 			"source_location_name": &requiredString,
 			"tags":                 &computedTags,
 			"name":                 &requiredString,
@@ -28,23 +29,25 @@ func dataSourceVodSource() *schema.Resource {
 	}
 }
 
-func dataSourceVodSourceRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
+func dataSourceVodSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	if err := setRegion(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
 	resourceName := d.Get("name").(string)
 	sourceLocationName := d.Get("source_location_name").(string)
 
 	input := &mediatailor.DescribeVodSourceInput{SourceLocationName: &(sourceLocationName), VodSourceName: aws.String(resourceName)}
 
-	res, err := client.DescribeVodSource(input)
+	res, err := client.DescribeVodSourceWithContext(ctx, input)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while reading the vod source: %v", err))
 	}
 
 	d.SetId(fmt.Sprintf("%q/%q", *res.SourceLocationName, *res.VodSourceName))
 
-	if err = setVodSource(res, d); err != nil {
-		return diag.FromErr(err)
+	if diags := setVodSource(res, d); diags.HasError() {
+		return diags
 	}
-
-	return nil
+	return diagnosticsFromErrors([]error{setAttribute(d, "tags", res.Tags)})
 }
@@ -0,0 +1,54 @@
+package awsmt
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccVodSourcesDataSourceBasic(t *testing.T) {
+	dataSourceName := "data.awsmt_vod_sources.test"
+	sourceLocationName := "vod_sources_data_source_location"
+	rName := "vod_sources_data_source_test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVodSourcesDataSourceBasic(sourceLocationName, rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", sourceLocationName),
+					resource.TestCheckResourceAttr(dataSourceName, "names.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "names.0", rName),
+					resource.TestMatchResourceAttr(dataSourceName, "arns.0", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:vodSource\/.*$`)),
+					resource.TestCheckResourceAttr(dataSourceName, "vod_sources.0.source_location_name", sourceLocationName),
+				),
+			},
+		},
+	})
+}
+
+func testAccVodSourcesDataSourceBasic(sourceLocationName, rName string) string {
+	return fmt.Sprintf(`
+resource "awsmt_source_location" "test" {
+  name                   = "%[1]s"
+  http_configuration_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/test-img.jpeg"
+}
+
+resource "awsmt_vod_source" "test" {
+  http_package_configurations {
+    path         = "/"
+    source_group = "default"
+    type         = "HLS"
+  }
+  source_location_name = awsmt_source_location.test.name
+  name                 = "%[2]s"
+}
+
+data "awsmt_vod_sources" "test" {
+  source_location_name = awsmt_source_location.test.name
+  name_regex           = "^${awsmt_vod_source.test.name}$"
+}
+`, sourceLocationName, rName)
+}
@@ -0,0 +1,1491 @@
+package awsmt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/private/protocol/json/jsonutil"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAccountId is the ID of the account returned by the fake STS GetCallerIdentity API.
+const fakeAccountId = "123456789012"
+
+// @ADR
+// Context: The acceptance tests need an AWS account and credentials, so that most contributors cannot run them.
+// Decision: We decided to implement an in-memory fake of the MediaTailor REST API, and of the STS GetCallerIdentity
+// API, that the provider reaches through the endpoints override. It reuses the request and response types of the SDK,
+// and only implements the validation rules the provider and the tests rely on.
+// Consequences: The fake must be updated when the provider starts using a new MediaTailor API, and passing the tests
+// against the fake does not guarantee that they pass against AWS, which remains the reference.
+
+// fakeError is an error returned by the fake MediaTailor API, serialized like the errors of the real API.
+type fakeError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *fakeError) Error() string {
+	return fmt.Sprintf("%s: %s", e.code, e.message)
+}
+
+func fakeNotFound(format string, args ...interface{}) error {
+	return &fakeError{status: http.StatusNotFound, code: "NotFoundException", message: fmt.Sprintf(format, args...)}
+}
+
+func fakeBadRequest(format string, args ...interface{}) error {
+	return &fakeError{status: http.StatusBadRequest, code: "BadRequestException", message: fmt.Sprintf(format, args...)}
+}
+
+// fakeRegion holds the MediaTailor resources of a single region.
+type fakeRegion struct {
+	name                   string
+	channels               map[string]*mediatailor.DescribeChannelOutput
+	channelPolicies        map[string]string
+	playbackConfigurations map[string]*mediatailor.GetPlaybackConfigurationOutput
+	prefetchSchedules      map[string]map[string]*mediatailor.GetPrefetchScheduleOutput
+	programs               map[string]map[string]*mediatailor.DescribeProgramOutput
+	sourceLocations        map[string]*mediatailor.DescribeSourceLocationOutput
+	vodSources             map[string]map[string]*mediatailor.DescribeVodSourceOutput
+	liveSources            map[string]map[string]*mediatailor.DescribeLiveSourceOutput
+	// tags are indexed by the ARN of every existing resource, including the resources without tags.
+	tags map[string]map[string]*string
+}
+
+func newFakeRegion(name string) *fakeRegion {
+	return &fakeRegion{
+		name:                   name,
+		channels:               map[string]*mediatailor.DescribeChannelOutput{},
+		channelPolicies:        map[string]string{},
+		playbackConfigurations: map[string]*mediatailor.GetPlaybackConfigurationOutput{},
+		prefetchSchedules:      map[string]map[string]*mediatailor.GetPrefetchScheduleOutput{},
+		programs:               map[string]map[string]*mediatailor.DescribeProgramOutput{},
+		sourceLocations:        map[string]*mediatailor.DescribeSourceLocationOutput{},
+		vodSources:             map[string]map[string]*mediatailor.DescribeVodSourceOutput{},
+		liveSources:            map[string]map[string]*mediatailor.DescribeLiveSourceOutput{},
+		tags:                   map[string]map[string]*string{},
+	}
+}
+
+func (s *fakeRegion) arn(resourceType string, names ...string) string {
+	return fmt.Sprintf("arn:aws:mediatailor:%s:%s:%s/%s", s.name, fakeAccountId, resourceType, strings.Join(names, "/"))
+}
+
+// fakeRoute maps a method and a path pattern, in the format used by the HTTPPath of the SDK operations, to a handler.
+type fakeRoute struct {
+	method  string
+	pattern string
+	handler func(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error)
+}
+
+// fakeMediaTailor is an http.Handler implementing the MediaTailor APIs used by the provider, keeping the resources of
+// every region in memory. The region of a request is read from the credential scope of its signature.
+type fakeMediaTailor struct {
+	mu      sync.Mutex
+	regions map[string]*fakeRegion
+	routes  []fakeRoute
+}
+
+func newFakeMediaTailor() *fakeMediaTailor {
+	f := &fakeMediaTailor{regions: map[string]*fakeRegion{}}
+	f.routes = []fakeRoute{
+		{http.MethodGet, "/alerts", f.listAlerts},
+		{http.MethodPost, "/channel/{ChannelName}", f.createChannel},
+		{http.MethodGet, "/channel/{ChannelName}", f.describeChannel},
+		{http.MethodPut, "/channel/{ChannelName}", f.updateChannel},
+		{http.MethodDelete, "/channel/{ChannelName}", f.deleteChannel},
+		{http.MethodPut, "/channel/{ChannelName}/start", f.startChannel},
+		{http.MethodPut, "/channel/{ChannelName}/stop", f.stopChannel},
+		{http.MethodGet, "/channel/{ChannelName}/policy", f.getChannelPolicy},
+		{http.MethodPut, "/channel/{ChannelName}/policy", f.putChannelPolicy},
+		{http.MethodDelete, "/channel/{ChannelName}/policy", f.deleteChannelPolicy},
+		{http.MethodGet, "/channel/{ChannelName}/schedule", f.getChannelSchedule},
+		{http.MethodPost, "/channel/{ChannelName}/program/{ProgramName}", f.createProgram},
+		{http.MethodGet, "/channel/{ChannelName}/program/{ProgramName}", f.describeProgram},
+		{http.MethodPut, "/channel/{ChannelName}/program/{ProgramName}", f.updateProgram},
+		{http.MethodDelete, "/channel/{ChannelName}/program/{ProgramName}", f.deleteProgram},
+		{http.MethodGet, "/channels", f.listChannels},
+		{http.MethodPut, "/configureLogs/channel", f.configureLogsForChannel},
+		{http.MethodPut, "/configureLogs/playbackConfiguration", f.configureLogsForPlaybackConfiguration},
+		{http.MethodPut, "/playbackConfiguration", f.putPlaybackConfiguration},
+		{http.MethodGet, "/playbackConfiguration/{Name}", f.getPlaybackConfiguration},
+		{http.MethodDelete, "/playbackConfiguration/{Name}", f.deletePlaybackConfiguration},
+		{http.MethodGet, "/playbackConfigurations", f.listPlaybackConfigurations},
+		{http.MethodPost, "/prefetchSchedule/{PlaybackConfigurationName}", f.listPrefetchSchedules},
+		{http.MethodPost, "/prefetchSchedule/{PlaybackConfigurationName}/{Name}", f.createPrefetchSchedule},
+		{http.MethodGet, "/prefetchSchedule/{PlaybackConfigurationName}/{Name}", f.getPrefetchSchedule},
+		{http.MethodDelete, "/prefetchSchedule/{PlaybackConfigurationName}/{Name}", f.deletePrefetchSchedule},
+		{http.MethodPost, "/sourceLocation/{SourceLocationName}", f.createSourceLocation},
+		{http.MethodGet, "/sourceLocation/{SourceLocationName}", f.describeSourceLocation},
+		{http.MethodPut, "/sourceLocation/{SourceLocationName}", f.updateSourceLocation},
+		{http.MethodDelete, "/sourceLocation/{SourceLocationName}", f.deleteSourceLocation},
+		{http.MethodGet, "/sourceLocation/{SourceLocationName}/liveSources", f.listLiveSources},
+		{http.MethodPost, "/sourceLocation/{SourceLocationName}/liveSource/{LiveSourceName}", f.createLiveSource},
+		{http.MethodGet, "/sourceLocation/{SourceLocationName}/liveSource/{LiveSourceName}", f.describeLiveSource},
+		{http.MethodPut, "/sourceLocation/{SourceLocationName}/liveSource/{LiveSourceName}", f.updateLiveSource},
+		{http.MethodDelete, "/sourceLocation/{SourceLocationName}/liveSource/{LiveSourceName}", f.deleteLiveSource},
+		{http.MethodGet, "/sourceLocation/{SourceLocationName}/vodSources", f.listVodSources},
+		{http.MethodPost, "/sourceLocation/{SourceLocationName}/vodSource/{VodSourceName}", f.createVodSource},
+		{http.MethodGet, "/sourceLocation/{SourceLocationName}/vodSource/{VodSourceName}", f.describeVodSource},
+		{http.MethodPut, "/sourceLocation/{SourceLocationName}/vodSource/{VodSourceName}", f.updateVodSource},
+		{http.MethodDelete, "/sourceLocation/{SourceLocationName}/vodSource/{VodSourceName}", f.deleteVodSource},
+		{http.MethodGet, "/sourceLocations", f.listSourceLocations},
+		{http.MethodPost, "/tags/{ResourceArn}", f.tagResource},
+		{http.MethodGet, "/tags/{ResourceArn}", f.listTagsForResource},
+		{http.MethodDelete, "/tags/{ResourceArn}", f.untagResource},
+	}
+	return f
+}
+
+var credentialScopeRegex = regexp.MustCompile(`Credential=[^/]+/\d+/([^/]+)/([^/]+)/aws4_request`)
+
+func (f *fakeMediaTailor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	region, service := defaultRegion, "mediatailor"
+	if m := credentialScopeRegex.FindStringSubmatch(r.Header.Get("Authorization")); m != nil {
+		region, service = m[1], m[2]
+	}
+	if service == "sts" {
+		f.getCallerIdentity(w)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.regions[region]
+	if !ok {
+		s = newFakeRegion(region)
+		f.regions[region] = s
+	}
+
+	for _, route := range f.routes {
+		params, ok := matchFakeRoute(route.pattern, r.URL.EscapedPath())
+		if !ok || route.method != r.Method {
+			continue
+		}
+		output, err := route.handler(s, params, r)
+		if err != nil {
+			writeFakeError(w, err)
+			return
+		}
+		body, err := jsonutil.BuildJSON(output)
+		if err != nil {
+			writeFakeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+		return
+	}
+	// not 404, which isNotFound would take for a missing resource, removing it from the state instead of failing the test
+	writeFakeError(w, &fakeError{status: http.StatusNotImplemented, code: "UnknownOperationException", message: fmt.Sprintf("%s %s is not implemented by the fake MediaTailor API", r.Method, r.URL.Path)})
+}
+
+func (f *fakeMediaTailor) getCallerIdentity(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/xml")
+	_, _ = fmt.Fprintf(w, `<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <GetCallerIdentityResult>
+    <Arn>arn:aws:iam::%[1]s:user/test</Arn>
+    <UserId>AIDACKCEVSQ6C2EXAMPLE</UserId>
+    <Account>%[1]s</Account>
+  </GetCallerIdentityResult>
+</GetCallerIdentityResponse>`, fakeAccountId)
+}
+
+// matchFakeRoute returns the unescaped path parameters if the path matches the pattern.
+func matchFakeRoute(pattern, path string) (map[string]string, bool) {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternSegments) != len(pathSegments) {
+		return nil, false
+	}
+	params := map[string]string{}
+	for i, segment := range patternSegments {
+		value, err := url.PathUnescape(pathSegments[i])
+		if err != nil {
+			return nil, false
+		}
+		if strings.HasPrefix(segment, "{") {
+			params[strings.Trim(segment, "{}")] = value
+		} else if segment != value {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func writeFakeError(w http.ResponseWriter, err error) {
+	e, ok := err.(*fakeError)
+	if !ok {
+		e = &fakeError{status: http.StatusInternalServerError, code: "InternalServerErrorException", message: err.Error()}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Amzn-Errortype", e.code)
+	w.WriteHeader(e.status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": e.message})
+}
+
+// readFakeInput unmarshals the body of the request into the input and validates the required parameters.
+func readFakeInput(r *http.Request, input interface{ Validate() error }) error {
+	if err := jsonutil.UnmarshalJSON(input, r.Body); err != nil {
+		return fakeBadRequest("invalid request body: %v", err)
+	}
+	if err := input.Validate(); err != nil {
+		return fakeBadRequest("%v", strings.ReplaceAll(err.Error(), "\n", " "))
+	}
+	return nil
+}
+
+// convertFake copies the fields shared by two SDK structures, for example from an input to the stored output.
+func convertFake(from, to interface{}) error {
+	body, err := jsonutil.BuildJSON(from)
+	if err != nil {
+		return err
+	}
+	return jsonutil.UnmarshalJSON(to, strings.NewReader(string(body)))
+}
+
+// fakePage returns the bounds of the requested page of a list of the given length, and the token of the next page.
+func fakePage(r *http.Request, maxResults *int64, nextToken *string, length int) (int, int, *string, error) {
+	query := r.URL.Query()
+	if v := query.Get("maxResults"); v != "" {
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, 0, nil, fakeBadRequest("invalid maxResults: %s", v)
+		}
+		maxResults = &i
+	}
+	if v := query.Get("nextToken"); v != "" {
+		nextToken = &v
+	}
+
+	start := 0
+	if nextToken != nil {
+		i, err := strconv.Atoi(*nextToken)
+		if err != nil || i < 0 || i > length {
+			return 0, 0, nil, fakeBadRequest("invalid nextToken: %s", *nextToken)
+		}
+		start = i
+	}
+	end := length
+	if maxResults != nil && *maxResults > 0 && start+int(*maxResults) < length {
+		end = start + int(*maxResults)
+		return start, end, aws.String(strconv.Itoa(end)), nil
+	}
+	return start, end, nil, nil
+}
+
+// sortedFakeKeys returns the sorted keys of a map of resources indexed by their names.
+func sortedFakeKeys(m interface{}) []string {
+	var keys []string
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *fakeRegion) createTags(arn string, tags map[string]*string) {
+	s.tags[arn] = map[string]*string{}
+	for k, v := range tags {
+		s.tags[arn][k] = v
+	}
+}
+
+func (s *fakeRegion) getTags(arn string) map[string]*string {
+	if len(s.tags[arn]) == 0 {
+		return nil
+	}
+	tags := map[string]*string{}
+	for k, v := range s.tags[arn] {
+		tags[k] = v
+	}
+	return tags
+}
+
+// channels
+
+func (s *fakeRegion) getChannel(name string) (*mediatailor.DescribeChannelOutput, error) {
+	channel, ok := s.channels[name]
+	if !ok {
+		return nil, fakeNotFound("Channel %s not found", name)
+	}
+	return channel, nil
+}
+
+func (s *fakeRegion) describeChannel(name string) (*mediatailor.DescribeChannelOutput, error) {
+	channel, err := s.getChannel(name)
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.DescribeChannelOutput{}
+	if err := convertFake(channel, output); err != nil {
+		return nil, err
+	}
+	output.Tags = s.getTags(aws.StringValue(channel.Arn))
+	return output, nil
+}
+
+func validateFakeChannel(playbackMode *string, fillerSlate *mediatailor.SlateSource, outputs []*mediatailor.RequestOutputItem) error {
+	for _, o := range outputs {
+		if (o.DashPlaylistSettings == nil) == (o.HlsPlaylistSettings == nil) {
+			return fakeBadRequest("The channel isn't valid. Every output must have exactly one of the DashPlaylistSettings attribute or the HlsPlaylistSettings attribute.")
+		}
+	}
+	if fillerSlate != nil && aws.StringValue(playbackMode) != mediatailor.PlaybackModeLinear {
+		return fakeBadRequest("The channel isn't valid. A filler slate can only be used with the LINEAR playback mode.")
+	}
+	return nil
+}
+
+func (s *fakeRegion) responseOutputs(name string, outputs []*mediatailor.RequestOutputItem) ([]*mediatailor.ResponseOutputItem, error) {
+	var result []*mediatailor.ResponseOutputItem
+	for _, o := range outputs {
+		temp := &mediatailor.ResponseOutputItem{}
+		if err := convertFake(o, temp); err != nil {
+			return nil, err
+		}
+		extension := "m3u8"
+		if o.DashPlaylistSettings != nil {
+			extension = "mpd"
+		}
+		temp.PlaybackUrl = aws.String(fmt.Sprintf("https://channel-assembly.mediatailor.%s.amazonaws.com/v1/channel/%s/%s.%s", s.name, name, aws.StringValue(o.ManifestName), extension))
+		result = append(result, temp)
+	}
+	return result, nil
+}
+
+func (f *fakeMediaTailor) createChannel(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.CreateChannelInput{ChannelName: aws.String(params["ChannelName"])}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	if _, ok := s.channels[params["ChannelName"]]; ok {
+		return nil, fakeBadRequest("Channel %s already exists", params["ChannelName"])
+	}
+	if err := validateFakeChannel(input.PlaybackMode, input.FillerSlate, input.Outputs); err != nil {
+		return nil, err
+	}
+	outputs, err := s.responseOutputs(params["ChannelName"], input.Outputs)
+	if err != nil {
+		return nil, err
+	}
+	tier := input.Tier
+	if tier == nil {
+		tier = aws.String(mediatailor.TierBasic)
+	}
+
+	now := time.Now()
+	channel := &mediatailor.DescribeChannelOutput{
+		Arn:              aws.String(s.arn("channel", params["ChannelName"])),
+		ChannelName:      aws.String(params["ChannelName"]),
+		ChannelState:     aws.String(mediatailor.ChannelStateStopped),
+		CreationTime:     &now,
+		FillerSlate:      input.FillerSlate,
+		LastModifiedTime: &now,
+		LogConfiguration: &mediatailor.LogConfigurationForChannel{},
+		Outputs:          outputs,
+		PlaybackMode:     input.PlaybackMode,
+		Tier:             tier,
+	}
+	s.channels[params["ChannelName"]] = channel
+	s.createTags(aws.StringValue(channel.Arn), input.Tags)
+
+	output := &mediatailor.CreateChannelOutput{}
+	channelOutput, err := s.describeChannel(params["ChannelName"])
+	if err != nil {
+		return nil, err
+	}
+	return output, convertFake(channelOutput, output)
+}
+
+func (f *fakeMediaTailor) describeChannel(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	return s.describeChannel(params["ChannelName"])
+}
+
+func (f *fakeMediaTailor) updateChannel(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.UpdateChannelInput{ChannelName: aws.String(params["ChannelName"])}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	channel, err := s.getChannel(params["ChannelName"])
+	if err != nil {
+		return nil, err
+	}
+	if aws.StringValue(channel.ChannelState) == mediatailor.ChannelStateRunning {
+		return nil, &fakeError{status: http.StatusConflict, code: "ConflictException", message: "The channel must be stopped before it can be updated"}
+	}
+	if err := validateFakeChannel(channel.PlaybackMode, input.FillerSlate, input.Outputs); err != nil {
+		return nil, err
+	}
+	outputs, err := s.responseOutputs(params["ChannelName"], input.Outputs)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	channel.FillerSlate = input.FillerSlate
+	channel.Outputs = outputs
+	channel.LastModifiedTime = &now
+
+	output := &mediatailor.UpdateChannelOutput{}
+	channelOutput, err := s.describeChannel(params["ChannelName"])
+	if err != nil {
+		return nil, err
+	}
+	return output, convertFake(channelOutput, output)
+}
+
+func (f *fakeMediaTailor) deleteChannel(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	channel, err := s.getChannel(params["ChannelName"])
+	if err != nil {
+		return nil, err
+	}
+	if aws.StringValue(channel.ChannelState) == mediatailor.ChannelStateRunning {
+		return nil, &fakeError{status: http.StatusConflict, code: "ConflictException", message: "The channel must be stopped before it can be deleted"}
+	}
+	delete(s.tags, aws.StringValue(channel.Arn))
+	for _, program := range s.programs[params["ChannelName"]] {
+		delete(s.tags, aws.StringValue(program.Arn))
+	}
+	delete(s.programs, params["ChannelName"])
+	delete(s.channelPolicies, params["ChannelName"])
+	delete(s.channels, params["ChannelName"])
+	return &mediatailor.DeleteChannelOutput{}, nil
+}
+
+func (f *fakeMediaTailor) startChannel(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	channel, err := s.getChannel(params["ChannelName"])
+	if err != nil {
+		return nil, err
+	}
+	channel.ChannelState = aws.String(mediatailor.ChannelStateRunning)
+	return &mediatailor.StartChannelOutput{}, nil
+}
+
+func (f *fakeMediaTailor) stopChannel(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	channel, err := s.getChannel(params["ChannelName"])
+	if err != nil {
+		return nil, err
+	}
+	channel.ChannelState = aws.String(mediatailor.ChannelStateStopped)
+	return &mediatailor.StopChannelOutput{}, nil
+}
+
+func (f *fakeMediaTailor) listChannels(s *fakeRegion, _ map[string]string, r *http.Request) (interface{}, error) {
+	keys := sortedFakeKeys(s.channels)
+	start, end, nextToken, err := fakePage(r, nil, nil, len(keys))
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.ListChannelsOutput{NextToken: nextToken}
+	for _, k := range keys[start:end] {
+		channel, err := s.describeChannel(k)
+		if err != nil {
+			return nil, err
+		}
+		item := &mediatailor.Channel{}
+		if err := convertFake(channel, item); err != nil {
+			return nil, err
+		}
+		output.Items = append(output.Items, item)
+	}
+	return output, nil
+}
+
+func (f *fakeMediaTailor) configureLogsForChannel(s *fakeRegion, _ map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.ConfigureLogsForChannelInput{}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	channel, err := s.getChannel(aws.StringValue(input.ChannelName))
+	if err != nil {
+		return nil, err
+	}
+	channel.LogConfiguration = &mediatailor.LogConfigurationForChannel{LogTypes: input.LogTypes}
+	return &mediatailor.ConfigureLogsForChannelOutput{ChannelName: input.ChannelName, LogTypes: input.LogTypes}, nil
+}
+
+// channel policies
+
+// fakeChannelPolicyActions are the only actions that can be allowed by a channel policy.
+var fakeChannelPolicyActions = map[string]bool{"mediatailor:GetManifest": true}
+
+func validateFakeChannelPolicy(policy string) error {
+	var document struct {
+		Statement []struct {
+			Action interface{}
+		}
+	}
+	if err := json.Unmarshal([]byte(policy), &document); err != nil {
+		return fakeBadRequest("The policy isn't a valid JSON document: %v", err)
+	}
+	var invalid []string
+	for _, statement := range document.Statement {
+		var actions []interface{}
+		switch v := statement.Action.(type) {
+		case string:
+			actions = append(actions, v)
+		case []interface{}:
+			actions = v
+		}
+		for _, a := range actions {
+			if action, _ := a.(string); !fakeChannelPolicyActions[action] {
+				invalid = append(invalid, fmt.Sprintf("%v", a))
+			}
+		}
+	}
+	if len(invalid) > 0 {
+		return fakeBadRequest("The following action names are invalid: %s", strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+func (f *fakeMediaTailor) getChannelPolicy(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	if _, err := s.getChannel(params["ChannelName"]); err != nil {
+		return nil, err
+	}
+	policy, ok := s.channelPolicies[params["ChannelName"]]
+	if !ok {
+		return nil, fakeNotFound("The channel %s has no policy", params["ChannelName"])
+	}
+	return &mediatailor.GetChannelPolicyOutput{Policy: aws.String(policy)}, nil
+}
+
+func (f *fakeMediaTailor) putChannelPolicy(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.PutChannelPolicyInput{ChannelName: aws.String(params["ChannelName"])}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	if _, err := s.getChannel(params["ChannelName"]); err != nil {
+		return nil, err
+	}
+	if err := validateFakeChannelPolicy(aws.StringValue(input.Policy)); err != nil {
+		return nil, err
+	}
+	s.channelPolicies[params["ChannelName"]] = aws.StringValue(input.Policy)
+	return &mediatailor.PutChannelPolicyOutput{}, nil
+}
+
+func (f *fakeMediaTailor) deleteChannelPolicy(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	if _, err := s.getChannel(params["ChannelName"]); err != nil {
+		return nil, err
+	}
+	delete(s.channelPolicies, params["ChannelName"])
+	return &mediatailor.DeleteChannelPolicyOutput{}, nil
+}
+
+// programs
+
+func (s *fakeRegion) getProgram(channelName, name string) (*mediatailor.DescribeProgramOutput, error) {
+	if _, err := s.getChannel(channelName); err != nil {
+		return nil, err
+	}
+	program, ok := s.programs[channelName][name]
+	if !ok {
+		return nil, fakeNotFound("Program %s not found in the channel %s", name, channelName)
+	}
+	return program, nil
+}
+
+func (f *fakeMediaTailor) createProgram(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.CreateProgramInput{ChannelName: aws.String(params["ChannelName"]), ProgramName: aws.String(params["ProgramName"])}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	if _, err := s.getChannel(params["ChannelName"]); err != nil {
+		return nil, err
+	}
+	if _, ok := s.programs[params["ChannelName"]][params["ProgramName"]]; ok {
+		return nil, fakeBadRequest("Program %s already exists in the channel %s", params["ProgramName"], params["ChannelName"])
+	}
+	if _, err := s.getSourceLocation(aws.StringValue(input.SourceLocationName)); err != nil {
+		return nil, err
+	}
+	if (input.VodSourceName == nil) == (input.LiveSourceName == nil) {
+		return nil, fakeBadRequest("The program isn't valid. It must have exactly one of the VodSourceName attribute or the LiveSourceName attribute.")
+	}
+	if input.VodSourceName != nil {
+		if _, err := s.getVodSource(aws.StringValue(input.SourceLocationName), aws.StringValue(input.VodSourceName)); err != nil {
+			return nil, err
+		}
+	}
+	if input.LiveSourceName != nil {
+		if _, err := s.getLiveSource(aws.StringValue(input.SourceLocationName), aws.StringValue(input.LiveSourceName)); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	program := &mediatailor.DescribeProgramOutput{
+		AdBreaks:           input.AdBreaks,
+		Arn:                aws.String(s.arn("program", params["ChannelName"], params["ProgramName"])),
+		ChannelName:        aws.String(params["ChannelName"]),
+		CreationTime:       &now,
+		LiveSourceName:     input.LiveSourceName,
+		ProgramName:        aws.String(params["ProgramName"]),
+		ScheduledStartTime: &now,
+		SourceLocationName: input.SourceLocationName,
+		VodSourceName:      input.VodSourceName,
+	}
+	if t := input.ScheduleConfiguration.Transition; t != nil {
+		program.DurationMillis = t.DurationMillis
+		if t.ScheduledStartTimeMillis != nil {
+			program.ScheduledStartTime = aws.Time(time.UnixMilli(*t.ScheduledStartTimeMillis))
+		}
+	}
+	if s.programs[params["ChannelName"]] == nil {
+		s.programs[params["ChannelName"]] = map[string]*mediatailor.DescribeProgramOutput{}
+	}
+	s.programs[params["ChannelName"]][params["ProgramName"]] = program
+	s.createTags(aws.StringValue(program.Arn), nil)
+
+	output := &mediatailor.CreateProgramOutput{}
+	return output, convertFake(program, output)
+}
+
+func (f *fakeMediaTailor) describeProgram(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	return s.getProgram(params["ChannelName"], params["ProgramName"])
+}
+
+func (f *fakeMediaTailor) updateProgram(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.UpdateProgramInput{ChannelName: aws.String(params["ChannelName"]), ProgramName: aws.String(params["ProgramName"])}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	program, err := s.getProgram(params["ChannelName"], params["ProgramName"])
+	if err != nil {
+		return nil, err
+	}
+	program.AdBreaks = input.AdBreaks
+	if input.ScheduleConfiguration != nil && input.ScheduleConfiguration.Transition != nil {
+		t := input.ScheduleConfiguration.Transition
+		if t.DurationMillis != nil {
+			program.DurationMillis = t.DurationMillis
+		}
+		if t.ScheduledStartTimeMillis != nil {
+			program.ScheduledStartTime = aws.Time(time.UnixMilli(*t.ScheduledStartTimeMillis))
+		}
+	}
+
+	output := &mediatailor.UpdateProgramOutput{}
+	return output, convertFake(program, output)
+}
+
+func (f *fakeMediaTailor) deleteProgram(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	program, err := s.getProgram(params["ChannelName"], params["ProgramName"])
+	if err != nil {
+		return nil, err
+	}
+	delete(s.tags, aws.StringValue(program.Arn))
+	delete(s.programs[params["ChannelName"]], params["ProgramName"])
+	return &mediatailor.DeleteProgramOutput{}, nil
+}
+
+func (f *fakeMediaTailor) getChannelSchedule(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	if _, err := s.getChannel(params["ChannelName"]); err != nil {
+		return nil, err
+	}
+	programs := s.programs[params["ChannelName"]]
+	keys := sortedFakeKeys(programs)
+	start, end, nextToken, err := fakePage(r, nil, nil, len(keys))
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.GetChannelScheduleOutput{NextToken: nextToken}
+	for _, k := range keys[start:end] {
+		program := programs[k]
+		output.Items = append(output.Items, &mediatailor.ScheduleEntry{
+			ApproximateDurationSeconds: aws.Int64(aws.Int64Value(program.DurationMillis) / 1000),
+			ApproximateStartTime:       program.ScheduledStartTime,
+			Arn:                        program.Arn,
+			ChannelName:                program.ChannelName,
+			LiveSourceName:             program.LiveSourceName,
+			ProgramName:                program.ProgramName,
+			ScheduleEntryType:          aws.String(mediatailor.ScheduleEntryTypeProgram),
+			SourceLocationName:         program.SourceLocationName,
+			VodSourceName:              program.VodSourceName,
+		})
+	}
+	return output, nil
+}
+
+func (f *fakeMediaTailor) listAlerts(s *fakeRegion, _ map[string]string, r *http.Request) (interface{}, error) {
+	arn := r.URL.Query().Get("resourceArn")
+	if _, ok := s.tags[arn]; !ok {
+		return nil, fakeNotFound("Resource %s not found", arn)
+	}
+	return &mediatailor.ListAlertsOutput{Items: []*mediatailor.Alert{}}, nil
+}
+
+// playback configurations
+
+func (s *fakeRegion) getPlaybackConfiguration(name string) (*mediatailor.GetPlaybackConfigurationOutput, error) {
+	playbackConfiguration, ok := s.playbackConfigurations[name]
+	if !ok {
+		return nil, fakeNotFound("Playback configuration %s not found", name)
+	}
+	output := &mediatailor.GetPlaybackConfigurationOutput{}
+	if err := convertFake(playbackConfiguration, output); err != nil {
+		return nil, err
+	}
+	output.Tags = s.getTags(aws.StringValue(playbackConfiguration.PlaybackConfigurationArn))
+	return output, nil
+}
+
+func (f *fakeMediaTailor) putPlaybackConfiguration(s *fakeRegion, _ map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.PutPlaybackConfigurationInput{}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	name := aws.StringValue(input.Name)
+	playbackConfiguration := &mediatailor.GetPlaybackConfigurationOutput{}
+	if err := convertFake(input, playbackConfiguration); err != nil {
+		return nil, err
+	}
+
+	// like the real API, the configuration groups that are not set are returned with their default values
+	if playbackConfiguration.AvailSuppression == nil {
+		playbackConfiguration.AvailSuppression = &mediatailor.AvailSuppression{}
+	}
+	if playbackConfiguration.AvailSuppression.Mode == nil {
+		playbackConfiguration.AvailSuppression.Mode = aws.String(mediatailor.ModeOff)
+	}
+	if playbackConfiguration.Bumper == nil {
+		playbackConfiguration.Bumper = &mediatailor.Bumper{}
+	}
+	if playbackConfiguration.CdnConfiguration == nil {
+		playbackConfiguration.CdnConfiguration = &mediatailor.CdnConfiguration{}
+	}
+	if playbackConfiguration.LivePreRollConfiguration == nil {
+		playbackConfiguration.LivePreRollConfiguration = &mediatailor.LivePreRollConfiguration{}
+	}
+	if playbackConfiguration.ManifestProcessingRules == nil {
+		playbackConfiguration.ManifestProcessingRules = &mediatailor.ManifestProcessingRules{}
+	}
+	if playbackConfiguration.ManifestProcessingRules.AdMarkerPassthrough == nil {
+		playbackConfiguration.ManifestProcessingRules.AdMarkerPassthrough = &mediatailor.AdMarkerPassthrough{}
+	}
+	if playbackConfiguration.ManifestProcessingRules.AdMarkerPassthrough.Enabled == nil {
+		playbackConfiguration.ManifestProcessingRules.AdMarkerPassthrough.Enabled = aws.Bool(false)
+	}
+
+	prefix := fmt.Sprintf("https://%s.mediatailor.%s.amazonaws.com", fakeAccountId, s.name)
+	if playbackConfiguration.DashConfiguration == nil {
+		playbackConfiguration.DashConfiguration = &mediatailor.DashConfiguration{}
+	}
+	playbackConfiguration.DashConfiguration.ManifestEndpointPrefix = aws.String(fmt.Sprintf("%s/v1/dash/%s/%s/", prefix, fakeAccountId, name))
+	playbackConfiguration.HlsConfiguration = &mediatailor.HlsConfiguration{ManifestEndpointPrefix: aws.String(fmt.Sprintf("%s/v1/master/%s/%s/", prefix, fakeAccountId, name))}
+	playbackConfiguration.LogConfiguration = &mediatailor.LogConfiguration{PercentEnabled: aws.Int64(0)}
+	playbackConfiguration.PlaybackConfigurationArn = aws.String(s.arn("playbackConfiguration", name))
+	playbackConfiguration.PlaybackEndpointPrefix = aws.String(prefix)
+	playbackConfiguration.SessionInitializationEndpointPrefix = aws.String(fmt.Sprintf("%s/v1/session/%s/%s/", prefix, fakeAccountId, name))
+	playbackConfiguration.Tags = nil
+
+	// PutPlaybackConfiguration creates or replaces the configuration, but keeps the log configuration and the tags
+	arn := aws.StringValue(playbackConfiguration.PlaybackConfigurationArn)
+	if existing, ok := s.playbackConfigurations[name]; ok {
+		playbackConfiguration.LogConfiguration = existing.LogConfiguration
+		for k, v := range input.Tags {
+			s.tags[arn][k] = v
+		}
+	} else {
+		s.createTags(arn, input.Tags)
+	}
+	s.playbackConfigurations[name] = playbackConfiguration
+
+	res, err := s.getPlaybackConfiguration(name)
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.PutPlaybackConfigurationOutput{}
+	return output, convertFake(res, output)
+}
+
+func (f *fakeMediaTailor) getPlaybackConfiguration(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	return s.getPlaybackConfiguration(params["Name"])
+}
+
+func (f *fakeMediaTailor) deletePlaybackConfiguration(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	playbackConfiguration, ok := s.playbackConfigurations[params["Name"]]
+	if !ok {
+		return nil, fakeNotFound("Playback configuration %s not found", params["Name"])
+	}
+	delete(s.tags, aws.StringValue(playbackConfiguration.PlaybackConfigurationArn))
+	for _, prefetchSchedule := range s.prefetchSchedules[params["Name"]] {
+		delete(s.tags, aws.StringValue(prefetchSchedule.Arn))
+	}
+	delete(s.prefetchSchedules, params["Name"])
+	delete(s.playbackConfigurations, params["Name"])
+	return &mediatailor.DeletePlaybackConfigurationOutput{}, nil
+}
+
+func (f *fakeMediaTailor) listPlaybackConfigurations(s *fakeRegion, _ map[string]string, r *http.Request) (interface{}, error) {
+	keys := sortedFakeKeys(s.playbackConfigurations)
+	start, end, nextToken, err := fakePage(r, nil, nil, len(keys))
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.ListPlaybackConfigurationsOutput{NextToken: nextToken}
+	for _, k := range keys[start:end] {
+		playbackConfiguration, err := s.getPlaybackConfiguration(k)
+		if err != nil {
+			return nil, err
+		}
+		output.Items = append(output.Items, (*mediatailor.PlaybackConfiguration)(playbackConfiguration))
+	}
+	return output, nil
+}
+
+func (f *fakeMediaTailor) configureLogsForPlaybackConfiguration(s *fakeRegion, _ map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.ConfigureLogsForPlaybackConfigurationInput{}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	playbackConfiguration, ok := s.playbackConfigurations[aws.StringValue(input.PlaybackConfigurationName)]
+	if !ok {
+		return nil, fakeNotFound("Playback configuration %s not found", aws.StringValue(input.PlaybackConfigurationName))
+	}
+	if p := aws.Int64Value(input.PercentEnabled); p < 0 || p > 100 {
+		return nil, fakeBadRequest("PercentEnabled must be between 0 and 100")
+	}
+	playbackConfiguration.LogConfiguration = &mediatailor.LogConfiguration{PercentEnabled: input.PercentEnabled}
+	return &mediatailor.ConfigureLogsForPlaybackConfigurationOutput{PercentEnabled: input.PercentEnabled, PlaybackConfigurationName: input.PlaybackConfigurationName}, nil
+}
+
+// prefetch schedules
+
+func (f *fakeMediaTailor) createPrefetchSchedule(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.CreatePrefetchScheduleInput{Name: aws.String(params["Name"]), PlaybackConfigurationName: aws.String(params["PlaybackConfigurationName"])}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	if _, ok := s.playbackConfigurations[params["PlaybackConfigurationName"]]; !ok {
+		return nil, fakeNotFound("Playback configuration %s not found", params["PlaybackConfigurationName"])
+	}
+	if _, ok := s.prefetchSchedules[params["PlaybackConfigurationName"]][params["Name"]]; ok {
+		return nil, fakeBadRequest("Prefetch schedule %s already exists", params["Name"])
+	}
+	if input.Retrieval.StartTime != nil && !input.Retrieval.StartTime.Before(*input.Retrieval.EndTime) {
+		return nil, fakeBadRequest("The retrieval start time must be before the retrieval end time")
+	}
+	if input.Consumption.StartTime != nil && !input.Consumption.StartTime.Before(*input.Consumption.EndTime) {
+		return nil, fakeBadRequest("The consumption start time must be before the consumption end time")
+	}
+
+	prefetchSchedule := &mediatailor.GetPrefetchScheduleOutput{}
+	if err := convertFake(input, prefetchSchedule); err != nil {
+		return nil, err
+	}
+	prefetchSchedule.Arn = aws.String(s.arn("prefetchSchedule", params["PlaybackConfigurationName"], params["Name"]))
+	prefetchSchedule.Name = aws.String(params["Name"])
+	prefetchSchedule.PlaybackConfigurationName = aws.String(params["PlaybackConfigurationName"])
+	if s.prefetchSchedules[params["PlaybackConfigurationName"]] == nil {
+		s.prefetchSchedules[params["PlaybackConfigurationName"]] = map[string]*mediatailor.GetPrefetchScheduleOutput{}
+	}
+	s.prefetchSchedules[params["PlaybackConfigurationName"]][params["Name"]] = prefetchSchedule
+	s.createTags(aws.StringValue(prefetchSchedule.Arn), nil)
+
+	output := &mediatailor.CreatePrefetchScheduleOutput{}
+	return output, convertFake(prefetchSchedule, output)
+}
+
+func (f *fakeMediaTailor) getPrefetchSchedule(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	prefetchSchedule, ok := s.prefetchSchedules[params["PlaybackConfigurationName"]][params["Name"]]
+	if !ok {
+		return nil, fakeNotFound("Prefetch schedule %s not found", params["Name"])
+	}
+	return prefetchSchedule, nil
+}
+
+func (f *fakeMediaTailor) deletePrefetchSchedule(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	prefetchSchedule, ok := s.prefetchSchedules[params["PlaybackConfigurationName"]][params["Name"]]
+	if !ok {
+		return nil, fakeNotFound("Prefetch schedule %s not found", params["Name"])
+	}
+	delete(s.tags, aws.StringValue(prefetchSchedule.Arn))
+	delete(s.prefetchSchedules[params["PlaybackConfigurationName"]], params["Name"])
+	return &mediatailor.DeletePrefetchScheduleOutput{}, nil
+}
+
+func (f *fakeMediaTailor) listPrefetchSchedules(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.ListPrefetchSchedulesInput{PlaybackConfigurationName: aws.String(params["PlaybackConfigurationName"])}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	if _, ok := s.playbackConfigurations[params["PlaybackConfigurationName"]]; !ok {
+		return nil, fakeNotFound("Playback configuration %s not found", params["PlaybackConfigurationName"])
+	}
+	prefetchSchedules := s.prefetchSchedules[params["PlaybackConfigurationName"]]
+	var keys []string
+	for _, k := range sortedFakeKeys(prefetchSchedules) {
+		if input.StreamId == nil || aws.StringValue(prefetchSchedules[k].StreamId) == aws.StringValue(input.StreamId) {
+			keys = append(keys, k)
+		}
+	}
+	start, end, nextToken, err := fakePage(r, input.MaxResults, input.NextToken, len(keys))
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.ListPrefetchSchedulesOutput{NextToken: nextToken}
+	for _, k := range keys[start:end] {
+		item := &mediatailor.PrefetchSchedule{}
+		if err := convertFake(prefetchSchedules[k], item); err != nil {
+			return nil, err
+		}
+		output.Items = append(output.Items, item)
+	}
+	return output, nil
+}
+
+// source locations
+
+func (s *fakeRegion) getSourceLocation(name string) (*mediatailor.DescribeSourceLocationOutput, error) {
+	sourceLocation, ok := s.sourceLocations[name]
+	if !ok {
+		return nil, fakeNotFound("Source location %s not found", name)
+	}
+	output := &mediatailor.DescribeSourceLocationOutput{}
+	if err := convertFake(sourceLocation, output); err != nil {
+		return nil, err
+	}
+	output.Tags = s.getTags(aws.StringValue(sourceLocation.Arn))
+	return output, nil
+}
+
+func (f *fakeMediaTailor) createSourceLocation(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.CreateSourceLocationInput{SourceLocationName: aws.String(params["SourceLocationName"])}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	if _, ok := s.sourceLocations[params["SourceLocationName"]]; ok {
+		return nil, fakeBadRequest("Source location %s already exists", params["SourceLocationName"])
+	}
+	sourceLocation := &mediatailor.DescribeSourceLocationOutput{}
+	if err := convertFake(input, sourceLocation); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	sourceLocation.Arn = aws.String(s.arn("sourceLocation", params["SourceLocationName"]))
+	sourceLocation.CreationTime = &now
+	sourceLocation.LastModifiedTime = &now
+	sourceLocation.SourceLocationName = aws.String(params["SourceLocationName"])
+	sourceLocation.Tags = nil
+	s.sourceLocations[params["SourceLocationName"]] = sourceLocation
+	s.createTags(aws.StringValue(sourceLocation.Arn), input.Tags)
+
+	res, err := s.getSourceLocation(params["SourceLocationName"])
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.CreateSourceLocationOutput{}
+	return output, convertFake(res, output)
+}
+
+func (f *fakeMediaTailor) describeSourceLocation(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	return s.getSourceLocation(params["SourceLocationName"])
+}
+
+func (f *fakeMediaTailor) updateSourceLocation(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.UpdateSourceLocationInput{SourceLocationName: aws.String(params["SourceLocationName"])}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	sourceLocation, ok := s.sourceLocations[params["SourceLocationName"]]
+	if !ok {
+		return nil, fakeNotFound("Source location %s not found", params["SourceLocationName"])
+	}
+	now := time.Now()
+	sourceLocation.AccessConfiguration = input.AccessConfiguration
+	sourceLocation.DefaultSegmentDeliveryConfiguration = input.DefaultSegmentDeliveryConfiguration
+	sourceLocation.HttpConfiguration = input.HttpConfiguration
+	sourceLocation.LastModifiedTime = &now
+	sourceLocation.SegmentDeliveryConfigurations = input.SegmentDeliveryConfigurations
+
+	res, err := s.getSourceLocation(params["SourceLocationName"])
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.UpdateSourceLocationOutput{}
+	return output, convertFake(res, output)
+}
+
+func (f *fakeMediaTailor) deleteSourceLocation(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	sourceLocation, ok := s.sourceLocations[params["SourceLocationName"]]
+	if !ok {
+		return nil, fakeNotFound("Source location %s not found", params["SourceLocationName"])
+	}
+	if len(s.vodSources[params["SourceLocationName"]]) > 0 || len(s.liveSources[params["SourceLocationName"]]) > 0 {
+		return nil, fakeBadRequest("Cannot delete the source location %s because it still contains sources", params["SourceLocationName"])
+	}
+	delete(s.tags, aws.StringValue(sourceLocation.Arn))
+	delete(s.sourceLocations, params["SourceLocationName"])
+	return &mediatailor.DeleteSourceLocationOutput{}, nil
+}
+
+func (f *fakeMediaTailor) listSourceLocations(s *fakeRegion, _ map[string]string, r *http.Request) (interface{}, error) {
+	keys := sortedFakeKeys(s.sourceLocations)
+	start, end, nextToken, err := fakePage(r, nil, nil, len(keys))
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.ListSourceLocationsOutput{NextToken: nextToken}
+	for _, k := range keys[start:end] {
+		sourceLocation, err := s.getSourceLocation(k)
+		if err != nil {
+			return nil, err
+		}
+		item := &mediatailor.SourceLocation{}
+		if err := convertFake(sourceLocation, item); err != nil {
+			return nil, err
+		}
+		output.Items = append(output.Items, item)
+	}
+	return output, nil
+}
+
+// vod sources
+
+func (s *fakeRegion) getVodSource(sourceLocationName, name string) (*mediatailor.DescribeVodSourceOutput, error) {
+	if _, ok := s.sourceLocations[sourceLocationName]; !ok {
+		return nil, fakeNotFound("Source location %s not found", sourceLocationName)
+	}
+	vodSource, ok := s.vodSources[sourceLocationName][name]
+	if !ok {
+		return nil, fakeNotFound("VOD source %s not found in the source location %s", name, sourceLocationName)
+	}
+	output := &mediatailor.DescribeVodSourceOutput{}
+	if err := convertFake(vodSource, output); err != nil {
+		return nil, err
+	}
+	output.Tags = s.getTags(aws.StringValue(vodSource.Arn))
+	return output, nil
+}
+
+func (f *fakeMediaTailor) createVodSource(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.CreateVodSourceInput{SourceLocationName: aws.String(params["SourceLocationName"]), VodSourceName: aws.String(params["VodSourceName"])}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	if _, ok := s.sourceLocations[params["SourceLocationName"]]; !ok {
+		return nil, fakeNotFound("Source location %s not found", params["SourceLocationName"])
+	}
+	if _, ok := s.vodSources[params["SourceLocationName"]][params["VodSourceName"]]; ok {
+		return nil, fakeBadRequest("VOD source %s already exists in the source location %s", params["VodSourceName"], params["SourceLocationName"])
+	}
+	vodSource := &mediatailor.DescribeVodSourceOutput{}
+	if err := convertFake(input, vodSource); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	vodSource.Arn = aws.String(s.arn("vodSource", params["SourceLocationName"], params["VodSourceName"]))
+	vodSource.CreationTime = &now
+	vodSource.LastModifiedTime = &now
+	vodSource.SourceLocationName = aws.String(params["SourceLocationName"])
+	vodSource.Tags = nil
+	vodSource.VodSourceName = aws.String(params["VodSourceName"])
+	if s.vodSources[params["SourceLocationName"]] == nil {
+		s.vodSources[params["SourceLocationName"]] = map[string]*mediatailor.DescribeVodSourceOutput{}
+	}
+	s.vodSources[params["SourceLocationName"]][params["VodSourceName"]] = vodSource
+	s.createTags(aws.StringValue(vodSource.Arn), input.Tags)
+
+	res, err := s.getVodSource(params["SourceLocationName"], params["VodSourceName"])
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.CreateVodSourceOutput{}
+	return output, convertFake(res, output)
+}
+
+func (f *fakeMediaTailor) describeVodSource(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	return s.getVodSource(params["SourceLocationName"], params["VodSourceName"])
+}
+
+func (f *fakeMediaTailor) updateVodSource(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.UpdateVodSourceInput{SourceLocationName: aws.String(params["SourceLocationName"]), VodSourceName: aws.String(params["VodSourceName"])}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	if _, err := s.getVodSource(params["SourceLocationName"], params["VodSourceName"]); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	vodSource := s.vodSources[params["SourceLocationName"]][params["VodSourceName"]]
+	vodSource.HttpPackageConfigurations = input.HttpPackageConfigurations
+	vodSource.LastModifiedTime = &now
+
+	res, err := s.getVodSource(params["SourceLocationName"], params["VodSourceName"])
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.UpdateVodSourceOutput{}
+	return output, convertFake(res, output)
+}
+
+func (f *fakeMediaTailor) deleteVodSource(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	vodSource, err := s.getVodSource(params["SourceLocationName"], params["VodSourceName"])
+	if err != nil {
+		return nil, err
+	}
+	delete(s.tags, aws.StringValue(vodSource.Arn))
+	delete(s.vodSources[params["SourceLocationName"]], params["VodSourceName"])
+	return &mediatailor.DeleteVodSourceOutput{}, nil
+}
+
+func (f *fakeMediaTailor) listVodSources(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	if _, ok := s.sourceLocations[params["SourceLocationName"]]; !ok {
+		return nil, fakeNotFound("Source location %s not found", params["SourceLocationName"])
+	}
+	keys := sortedFakeKeys(s.vodSources[params["SourceLocationName"]])
+	start, end, nextToken, err := fakePage(r, nil, nil, len(keys))
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.ListVodSourcesOutput{NextToken: nextToken}
+	for _, k := range keys[start:end] {
+		vodSource, err := s.getVodSource(params["SourceLocationName"], k)
+		if err != nil {
+			return nil, err
+		}
+		item := &mediatailor.VodSource{}
+		if err := convertFake(vodSource, item); err != nil {
+			return nil, err
+		}
+		output.Items = append(output.Items, item)
+	}
+	return output, nil
+}
+
+// live sources
+
+func (s *fakeRegion) getLiveSource(sourceLocationName, name string) (*mediatailor.DescribeLiveSourceOutput, error) {
+	if _, ok := s.sourceLocations[sourceLocationName]; !ok {
+		return nil, fakeNotFound("Source location %s not found", sourceLocationName)
+	}
+	liveSource, ok := s.liveSources[sourceLocationName][name]
+	if !ok {
+		return nil, fakeNotFound("Live source %s not found in the source location %s", name, sourceLocationName)
+	}
+	output := &mediatailor.DescribeLiveSourceOutput{}
+	if err := convertFake(liveSource, output); err != nil {
+		return nil, err
+	}
+	output.Tags = s.getTags(aws.StringValue(liveSource.Arn))
+	return output, nil
+}
+
+func (f *fakeMediaTailor) createLiveSource(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.CreateLiveSourceInput{SourceLocationName: aws.String(params["SourceLocationName"]), LiveSourceName: aws.String(params["LiveSourceName"])}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	if _, ok := s.sourceLocations[params["SourceLocationName"]]; !ok {
+		return nil, fakeNotFound("Source location %s not found", params["SourceLocationName"])
+	}
+	if _, ok := s.liveSources[params["SourceLocationName"]][params["LiveSourceName"]]; ok {
+		return nil, fakeBadRequest("Live source %s already exists in the source location %s", params["LiveSourceName"], params["SourceLocationName"])
+	}
+	liveSource := &mediatailor.DescribeLiveSourceOutput{}
+	if err := convertFake(input, liveSource); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	liveSource.Arn = aws.String(s.arn("liveSource", params["SourceLocationName"], params["LiveSourceName"]))
+	liveSource.CreationTime = &now
+	liveSource.LastModifiedTime = &now
+	liveSource.LiveSourceName = aws.String(params["LiveSourceName"])
+	liveSource.SourceLocationName = aws.String(params["SourceLocationName"])
+	liveSource.Tags = nil
+	if s.liveSources[params["SourceLocationName"]] == nil {
+		s.liveSources[params["SourceLocationName"]] = map[string]*mediatailor.DescribeLiveSourceOutput{}
+	}
+	s.liveSources[params["SourceLocationName"]][params["LiveSourceName"]] = liveSource
+	s.createTags(aws.StringValue(liveSource.Arn), input.Tags)
+
+	res, err := s.getLiveSource(params["SourceLocationName"], params["LiveSourceName"])
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.CreateLiveSourceOutput{}
+	return output, convertFake(res, output)
+}
+
+func (f *fakeMediaTailor) describeLiveSource(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	return s.getLiveSource(params["SourceLocationName"], params["LiveSourceName"])
+}
+
+func (f *fakeMediaTailor) updateLiveSource(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.UpdateLiveSourceInput{SourceLocationName: aws.String(params["SourceLocationName"]), LiveSourceName: aws.String(params["LiveSourceName"])}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	if _, err := s.getLiveSource(params["SourceLocationName"], params["LiveSourceName"]); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	liveSource := s.liveSources[params["SourceLocationName"]][params["LiveSourceName"]]
+	liveSource.HttpPackageConfigurations = input.HttpPackageConfigurations
+	liveSource.LastModifiedTime = &now
+
+	res, err := s.getLiveSource(params["SourceLocationName"], params["LiveSourceName"])
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.UpdateLiveSourceOutput{}
+	return output, convertFake(res, output)
+}
+
+func (f *fakeMediaTailor) deleteLiveSource(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	liveSource, err := s.getLiveSource(params["SourceLocationName"], params["LiveSourceName"])
+	if err != nil {
+		return nil, err
+	}
+	delete(s.tags, aws.StringValue(liveSource.Arn))
+	delete(s.liveSources[params["SourceLocationName"]], params["LiveSourceName"])
+	return &mediatailor.DeleteLiveSourceOutput{}, nil
+}
+
+func (f *fakeMediaTailor) listLiveSources(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	if _, ok := s.sourceLocations[params["SourceLocationName"]]; !ok {
+		return nil, fakeNotFound("Source location %s not found", params["SourceLocationName"])
+	}
+	keys := sortedFakeKeys(s.liveSources[params["SourceLocationName"]])
+	start, end, nextToken, err := fakePage(r, nil, nil, len(keys))
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.ListLiveSourcesOutput{NextToken: nextToken}
+	for _, k := range keys[start:end] {
+		liveSource, err := s.getLiveSource(params["SourceLocationName"], k)
+		if err != nil {
+			return nil, err
+		}
+		item := &mediatailor.LiveSource{}
+		if err := convertFake(liveSource, item); err != nil {
+			return nil, err
+		}
+		output.Items = append(output.Items, item)
+	}
+	return output, nil
+}
+
+// tags
+
+func (s *fakeRegion) getResourceTags(arn string) (map[string]*string, error) {
+	tags, ok := s.tags[arn]
+	if !ok {
+		return nil, fakeNotFound("Resource %s not found", arn)
+	}
+	return tags, nil
+}
+
+func (f *fakeMediaTailor) tagResource(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	input := &mediatailor.TagResourceInput{ResourceArn: aws.String(params["ResourceArn"])}
+	if err := readFakeInput(r, input); err != nil {
+		return nil, err
+	}
+	tags, err := s.getResourceTags(params["ResourceArn"])
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range input.Tags {
+		tags[k] = v
+	}
+	return &mediatailor.TagResourceOutput{}, nil
+}
+
+func (f *fakeMediaTailor) listTagsForResource(s *fakeRegion, params map[string]string, _ *http.Request) (interface{}, error) {
+	if _, err := s.getResourceTags(params["ResourceArn"]); err != nil {
+		return nil, err
+	}
+	return &mediatailor.ListTagsForResourceOutput{Tags: s.getTags(params["ResourceArn"])}, nil
+}
+
+func (f *fakeMediaTailor) untagResource(s *fakeRegion, params map[string]string, r *http.Request) (interface{}, error) {
+	tags, err := s.getResourceTags(params["ResourceArn"])
+	if err != nil {
+		return nil, err
+	}
+	keys := r.URL.Query()["tagKeys"]
+	if len(keys) == 0 {
+		return nil, fakeBadRequest("tagKeys is required")
+	}
+	for _, k := range keys {
+		delete(tags, k)
+	}
+	return &mediatailor.UntagResourceOutput{}, nil
+}
+
+func newTestFakeClient(t *testing.T, server *httptest.Server, region string) *mediatailor.MediaTailor {
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials("test", "test", ""),
+		Endpoint:    aws.String(server.URL),
+		Region:      aws.String(region),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mediatailor.New(sess)
+}
+
+func TestFakeMediaTailor_errors(t *testing.T) {
+	// arrange
+	server := httptest.NewServer(newFakeMediaTailor())
+	defer server.Close()
+	client := newTestFakeClient(t, server, "eu-central-1")
+	httpConfiguration := &mediatailor.HttpConfiguration{BaseUrl: aws.String("https://www.example.com")}
+	httpPackageConfigurations := []*mediatailor.HttpPackageConfiguration{{Path: aws.String("/"), SourceGroup: aws.String("default"), Type: aws.String("HLS")}}
+	hlsOutputs := []*mediatailor.RequestOutputItem{{ManifestName: aws.String("default"), SourceGroup: aws.String("default"), HlsPlaylistSettings: &mediatailor.HlsPlaylistSettings{}}}
+	if _, err := client.CreateSourceLocation(&mediatailor.CreateSourceLocationInput{SourceLocationName: aws.String("location"), HttpConfiguration: httpConfiguration}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateVodSource(&mediatailor.CreateVodSourceInput{SourceLocationName: aws.String("location"), VodSourceName: aws.String("vod"), HttpPackageConfigurations: httpPackageConfigurations}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateChannel(&mediatailor.CreateChannelInput{ChannelName: aws.String("running"), Outputs: hlsOutputs, PlaybackMode: aws.String("LOOP")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.StartChannel(&mediatailor.StartChannelInput{ChannelName: aws.String("running")}); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		call     func() error
+		code     string
+		expected string
+	}{
+		{"missing channel", func() error {
+			_, err := client.DescribeChannel(&mediatailor.DescribeChannelInput{ChannelName: aws.String("missing")})
+			return err
+		}, "NotFoundException", "Channel missing not found"},
+		{"missing vod source", func() error {
+			_, err := client.DescribeVodSource(&mediatailor.DescribeVodSourceInput{SourceLocationName: aws.String("location"), VodSourceName: aws.String("missing")})
+			return err
+		}, "NotFoundException", "VOD source missing not found"},
+		{"duplicate source location", func() error {
+			_, err := client.CreateSourceLocation(&mediatailor.CreateSourceLocationInput{SourceLocationName: aws.String("location"), HttpConfiguration: httpConfiguration})
+			return err
+		}, "BadRequestException", "already exists"},
+		{"invalid outputs", func() error {
+			_, err := client.CreateChannel(&mediatailor.CreateChannelInput{ChannelName: aws.String("invalid"), PlaybackMode: aws.String("LOOP"), Outputs: []*mediatailor.RequestOutputItem{{ManifestName: aws.String("default"), SourceGroup: aws.String("default")}}})
+			return err
+		}, "BadRequestException", "Every output must have exactly one of the DashPlaylistSettings attribute or the HlsPlaylistSettings attribute"},
+		{"invalid policy action", func() error {
+			_, err := client.PutChannelPolicy(&mediatailor.PutChannelPolicyInput{ChannelName: aws.String("running"), Policy: aws.String(`{"Statement":[{"Effect":"Allow","Action":["mediatailor:GetChannelSchedule"]}]}`)})
+			return err
+		}, "BadRequestException", "The following action names are invalid: mediatailor:GetChannelSchedule"},
+		{"running channel deletion", func() error {
+			_, err := client.DeleteChannel(&mediatailor.DeleteChannelInput{ChannelName: aws.String("running")})
+			return err
+		}, "ConflictException", "must be stopped"},
+		{"source location deletion with sources", func() error {
+			_, err := client.DeleteSourceLocation(&mediatailor.DeleteSourceLocationInput{SourceLocationName: aws.String("location")})
+			return err
+		}, "BadRequestException", "still contains sources"},
+		{"unknown operation", func() error {
+			return client.NewRequest(&request.Operation{Name: "Unknown", HTTPMethod: http.MethodGet, HTTPPath: "/unknown"}, nil, nil).Send()
+		}, "UnknownOperationException", "is not implemented by the fake MediaTailor API"},
+	}
+
+	for _, c := range cases {
+		// act
+		err := c.call()
+
+		// assert
+		var awsErr awserr.Error
+		if !errors.As(err, &awsErr) {
+			t.Errorf("%s: expected an AWS error, got %v", c.name, err)
+			continue
+		}
+		if awsErr.Code() != c.code || !strings.Contains(awsErr.Message(), c.expected) {
+			t.Errorf("%s: expected %s containing %q, got %v", c.name, c.code, c.expected, err)
+		}
+		if isNotFound(err) != (c.code == "NotFoundException") {
+			t.Errorf("%s: expected the error to be recognized as NotFound only for NotFoundException", c.name)
+		}
+	}
+}
+
+func TestFakeMediaTailor_pagination(t *testing.T) {
+	// arrange
+	server := httptest.NewServer(newFakeMediaTailor())
+	defer server.Close()
+	client := newTestFakeClient(t, server, "eu-central-1")
+	if _, err := client.CreateSourceLocation(&mediatailor.CreateSourceLocationInput{SourceLocationName: aws.String("location"), HttpConfiguration: &mediatailor.HttpConfiguration{BaseUrl: aws.String("https://www.example.com")}}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"vod_1", "vod_2", "vod_3"} {
+		input := &mediatailor.CreateVodSourceInput{SourceLocationName: aws.String("location"), VodSourceName: aws.String(name), HttpPackageConfigurations: []*mediatailor.HttpPackageConfiguration{{Path: aws.String("/"), SourceGroup: aws.String("default"), Type: aws.String("HLS")}}}
+		if _, err := client.CreateVodSource(input); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// act
+	var pages int
+	var names []string
+	err := client.ListVodSourcesPages(&mediatailor.ListVodSourcesInput{SourceLocationName: aws.String("location"), MaxResults: aws.Int64(2)}, func(output *mediatailor.ListVodSourcesOutput, _ bool) bool {
+		pages++
+		for _, item := range output.Items {
+			names = append(names, aws.StringValue(item.VodSourceName))
+		}
+		return true
+	})
+
+	// assert
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"vod_1", "vod_2", "vod_3"}; pages != 2 || !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v in 2 pages, got %v in %d pages", expected, names, pages)
+	}
+}
+
+func TestFakeMediaTailor_regionsAndTags(t *testing.T) {
+	// arrange
+	server := httptest.NewServer(newFakeMediaTailor())
+	defer server.Close()
+	client := newTestFakeClient(t, server, "eu-central-1")
+	otherClient := newTestFakeClient(t, server, "us-east-1")
+	input := &mediatailor.PutPlaybackConfigurationInput{Name: aws.String("config"), Tags: map[string]*string{"Environment": aws.String("dev"), "Team": aws.String("video")}}
+
+	// act
+	res, err := client.PutPlaybackConfiguration(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherErr := otherClient.GetPlaybackConfiguration(&mediatailor.GetPlaybackConfigurationInput{Name: aws.String("config")})
+	_, untagErr := client.UntagResource(&mediatailor.UntagResourceInput{ResourceArn: res.PlaybackConfigurationArn, TagKeys: []*string{aws.String("Team")}})
+	tags, tagsErr := client.ListTagsForResource(&mediatailor.ListTagsForResourceInput{ResourceArn: res.PlaybackConfigurationArn})
+
+	// assert
+	if expected := "arn:aws:mediatailor:eu-central-1:123456789012:playbackConfiguration/config"; aws.StringValue(res.PlaybackConfigurationArn) != expected {
+		t.Errorf("expected the ARN %s, got %s", expected, aws.StringValue(res.PlaybackConfigurationArn))
+	}
+	if !isNotFound(otherErr) {
+		t.Errorf("expected the playback configuration not to exist in another region, got %v", otherErr)
+	}
+	if untagErr != nil || tagsErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", untagErr, tagsErr)
+	}
+	if expected := map[string]*string{"Environment": aws.String("dev")}; !reflect.DeepEqual(tags.Tags, expected) {
+		t.Errorf("expected the tags %v, got %v", expected, tags.Tags)
+	}
+}
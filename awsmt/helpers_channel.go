@@ -1,12 +1,17 @@
 package awsmt
 
 import (
+	"context"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/aws/aws-sdk-go/service/mediatailor/mediatailoriface"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"strings"
+	"time"
 )
 
 func getFillerSlate(d *schema.ResourceData) *mediatailor.SlateSource {
@@ -76,14 +81,14 @@ func getUpdateChannelInput(d *schema.ResourceData) mediatailor.UpdateChannelInpu
 	return params
 }
 
-func createChannelPolicy(client *mediatailor.MediaTailor, d *schema.ResourceData) error {
+func createChannelPolicy(ctx context.Context, client mediatailoriface.MediaTailorAPI, d *schema.ResourceData) error {
 	if v, ok := d.GetOk("policy"); ok {
 		var putChannelPolicyParams = mediatailor.PutChannelPolicyInput{
 			ChannelName: aws.String((d.Get("name")).(string)),
 			Policy:      aws.String(v.(string)),
 		}
 
-		_, err := client.PutChannelPolicy(&putChannelPolicyParams)
+		_, err := client.PutChannelPolicyWithContext(ctx, &putChannelPolicyParams)
 		if err != nil {
 			return fmt.Errorf("error while creating the policy: %v", err)
 		}
@@ -91,25 +96,43 @@ func createChannelPolicy(client *mediatailor.MediaTailor, d *schema.ResourceData
 	return nil
 }
 
-func updateChannelPolicy(client *mediatailor.MediaTailor, d *schema.ResourceData, channelName *string) error {
-	_, err := client.PutChannelPolicy(&mediatailor.PutChannelPolicyInput{ChannelName: channelName, Policy: aws.String(d.Get("policy").(string))})
+func updateChannelPolicy(ctx context.Context, client mediatailoriface.MediaTailorAPI, d *schema.ResourceData, channelName *string) error {
+	_, err := client.PutChannelPolicyWithContext(ctx, &mediatailor.PutChannelPolicyInput{ChannelName: channelName, Policy: aws.String(d.Get("policy").(string))})
 
-	if err != nil && !strings.Contains(err.Error(), "NotFound") {
+	if err != nil && !isNotFound(err) {
 		return fmt.Errorf("error while getting the channel policy: %v", err)
 	}
 	return nil
 }
 
-func updatePolicy(client *mediatailor.MediaTailor, d *schema.ResourceData, channelName *string) error {
+// checkNoChannelPolicy returns an error if the channel already has a policy, which is then managed by the
+// awsmt_channel_policy resource or outside Terraform.
+func checkNoChannelPolicy(ctx context.Context, client mediatailoriface.MediaTailorAPI, channelName *string) error {
+	existing, err := client.GetChannelPolicyWithContext(ctx, &mediatailor.GetChannelPolicyInput{ChannelName: channelName})
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("error while getting the channel policy: %v", err)
+	}
+	if err == nil && aws.StringValue(existing.Policy) != "" {
+		return fmt.Errorf("the channel '%s' already has a policy. Remove the awsmt_channel_policy resource managing it, or delete the policy to manage it with the policy attribute", aws.StringValue(channelName))
+	}
+	return nil
+}
+
+func updatePolicy(ctx context.Context, client mediatailoriface.MediaTailorAPI, d *schema.ResourceData, channelName *string) error {
 	if d.HasChange("policy") {
-		_, newValue := d.GetChange("policy")
+		oldValue, newValue := d.GetChange("policy")
 		if len(newValue.(string)) > 0 {
-			err := updateChannelPolicy(client, d, channelName)
+			if len(oldValue.(string)) == 0 {
+				if err := checkNoChannelPolicy(ctx, client, channelName); err != nil {
+					return err
+				}
+			}
+			err := updateChannelPolicy(ctx, client, d, channelName)
 			if err != nil {
 				return err
 			}
 		} else {
-			err := deleteChannelPolicy(client, d, channelName)
+			err := deleteChannelPolicy(ctx, client, d, channelName)
 			if err != nil {
 				return err
 			}
@@ -118,8 +141,8 @@ func updatePolicy(client *mediatailor.MediaTailor, d *schema.ResourceData, chann
 	return nil
 }
 
-func deleteChannelPolicy(client *mediatailor.MediaTailor, d *schema.ResourceData, channelName *string) error {
-	_, err := client.DeleteChannelPolicy(&mediatailor.DeleteChannelPolicyInput{ChannelName: channelName})
+func deleteChannelPolicy(ctx context.Context, client mediatailoriface.MediaTailorAPI, d *schema.ResourceData, channelName *string) error {
+	_, err := client.DeleteChannelPolicyWithContext(ctx, &mediatailor.DeleteChannelPolicyInput{ChannelName: channelName})
 	if err != nil {
 		return fmt.Errorf("error while deleting the policy: %v", err)
 	}
@@ -129,6 +152,28 @@ func deleteChannelPolicy(client *mediatailor.MediaTailor, d *schema.ResourceData
 	return nil
 }
 
+func getLogTypes(d *schema.ResourceData) []*string {
+	logTypes := []*string{}
+	if v, ok := d.GetOk("log_configuration"); ok && v.([]interface{})[0] != nil {
+		val := v.([]interface{})[0].(map[string]interface{})
+		for _, t := range val["log_types"].(*schema.Set).List() {
+			logTypes = append(logTypes, aws.String(t.(string)))
+		}
+	}
+	return logTypes
+}
+
+func configureLogsForChannel(ctx context.Context, client mediatailoriface.MediaTailorAPI, d *schema.ResourceData) error {
+	_, err := client.ConfigureLogsForChannelWithContext(ctx, &mediatailor.ConfigureLogsForChannelInput{
+		ChannelName: aws.String(d.Get("name").(string)),
+		LogTypes:    getLogTypes(d),
+	})
+	if err != nil {
+		return fmt.Errorf("error while configuring the logs for the channel: %v", err)
+	}
+	return nil
+}
+
 func getResourceName(d *schema.ResourceData, fieldName string) (*string, error) {
 	resourceName := d.Get(fieldName).(string)
 	if len(resourceName) == 0 && len(d.Id()) > 0 {
@@ -151,13 +196,26 @@ func setFillerState(values *mediatailor.DescribeChannelOutput, d *schema.Resourc
 		if values.FillerSlate.VodSourceName != nil {
 			temp["vod_source_name"] = values.FillerSlate.VodSourceName
 		}
-		if err := d.Set("filler_slate", []interface{}{temp}); err != nil {
+		if err := setAttribute(d, "filler_slate", []interface{}{temp}); err != nil {
 			return fmt.Errorf("error while setting the filler slate: %w", err)
 		}
 	}
 	return nil
 }
 
+func setLogConfiguration(values *mediatailor.DescribeChannelOutput, d *schema.ResourceData) error {
+	var logConfiguration []interface{}
+	if values.LogConfiguration != nil && len(values.LogConfiguration.LogTypes) > 0 {
+		logConfiguration = []interface{}{map[string]interface{}{
+			"log_types": aws.StringValueSlice(values.LogConfiguration.LogTypes),
+		}}
+	}
+	if err := setAttribute(d, "log_configuration", logConfiguration); err != nil {
+		return fmt.Errorf("error while setting the log configuration: %w", err)
+	}
+	return nil
+}
+
 func flattenOutput(o *mediatailor.ResponseOutputItem) map[string]interface{} {
 	temp := map[string]interface{}{}
 	temp["manifest_name"] = o.ManifestName
@@ -191,39 +249,37 @@ func setOutputs(values *mediatailor.DescribeChannelOutput, d *schema.ResourceDat
 		temp := flattenOutput(o)
 		outputs = append(outputs, temp)
 	}
-	if err := d.Set("outputs", outputs); err != nil {
+	if err := setAttribute(d, "outputs", outputs); err != nil {
 		return fmt.Errorf("error while setting the outputs: %w", err)
 	}
 	return nil
 }
 
-func setChannel(res *mediatailor.DescribeChannelOutput, d *schema.ResourceData) error {
+func setChannel(res *mediatailor.DescribeChannelOutput, d *schema.ResourceData) diag.Diagnostics {
 	var errors []error
 
-	errors = append(errors, d.Set("arn", res.Arn))
-	errors = append(errors, d.Set("name", res.ChannelName))
-	errors = append(errors, d.Set("channel_state", res.ChannelState))
-	errors = append(errors, d.Set("creation_time", res.CreationTime.String()))
+	errors = append(errors, setAttribute(d, "arn", res.Arn))
+	errors = append(errors, setAttribute(d, "name", res.ChannelName))
+	errors = append(errors, setAttribute(d, "channel_state", res.ChannelState))
+	errors = append(errors, setAttribute(d, "creation_time", res.CreationTime.String()))
 	errors = append(errors, setFillerState(res, d))
-	errors = append(errors, d.Set("last_modified_time", res.LastModifiedTime.String()))
+	errors = append(errors, setAttribute(d, "last_modified_time", res.LastModifiedTime.String()))
+	errors = append(errors, setLogConfiguration(res, d))
 	errors = append(errors, setOutputs(res, d))
-	errors = append(errors, d.Set("tags", res.Tags))
-	errors = append(errors, d.Set("playback_mode", res.PlaybackMode))
-	errors = append(errors, d.Set("tier", res.Tier))
+	errors = append(errors, setAttribute(d, "playback_mode", res.PlaybackMode))
+	errors = append(errors, setAttribute(d, "tier", res.Tier))
 
-	for _, e := range errors {
-		if e != nil {
-			return fmt.Errorf("the following error occured while setting the values: %w", e)
-		}
-	}
-	return nil
+	return diagnosticsFromErrors(errors)
 }
 
+// setChannelPolicy sets the policy of the channel, or an empty policy if the channel has none.
 func setChannelPolicy(res *mediatailor.GetChannelPolicyOutput, d *schema.ResourceData) error {
-	if res.Policy != nil {
-		if err := d.Set("policy", res.Policy); err != nil {
-			return fmt.Errorf("error while setting the  the channel policy: %v", err)
-		}
+	var policy *string
+	if res != nil {
+		policy = res.Policy
+	}
+	if err := setAttribute(d, "policy", aws.StringValue(policy)); err != nil {
+		return fmt.Errorf("error while setting the  the channel policy: %v", err)
 	}
 	return nil
 }
@@ -281,30 +337,91 @@ func getOutputs(d *schema.ResourceData) []*mediatailor.RequestOutputItem {
 	return nil
 }
 
-func startChannel(client *mediatailor.MediaTailor, channelName string) error {
-	_, err := client.StartChannel(&mediatailor.StartChannelInput{
+// channelStatePollInterval is the interval between two DescribeChannel calls while waiting for a channel state.
+var channelStatePollInterval = 5 * time.Second
+
+// @ADR
+// Context: StartChannel and StopChannel return before the state of the channel changes, and the calls made right
+// after them, for example UpdateChannel on a channel that is still running, intermittently fail with a conflict.
+// Decision: We decided to poll DescribeChannel after starting or stopping a channel until it reaches the expected
+// state, bounded by the timeout of the current operation.
+// Consequences: Starting and stopping a channel takes at least one more API call, and applies can take longer, but
+// the calls following a state change no longer race with it.
+
+// waitForChannelState polls the channel until it reaches the given state, or until the timeout expires.
+func waitForChannelState(ctx context.Context, client mediatailoriface.MediaTailorAPI, channelName, state string, timeout time.Duration) error {
+	pending := mediatailor.ChannelStateRunning
+	if state == mediatailor.ChannelStateRunning {
+		pending = mediatailor.ChannelStateStopped
+	}
+	conf := &resource.StateChangeConf{
+		Pending: []string{pending},
+		Target:  []string{state},
+		Refresh: func() (interface{}, string, error) {
+			res, err := client.DescribeChannelWithContext(ctx, &mediatailor.DescribeChannelInput{ChannelName: aws.String(channelName)})
+			if err != nil {
+				return nil, "", err
+			}
+			return res, aws.StringValue(res.ChannelState), nil
+		},
+		Timeout:      timeout,
+		PollInterval: channelStatePollInterval,
+	}
+	if _, err := conf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error while waiting for the channel to be %s: %v", strings.ToLower(state), err)
+	}
+	return nil
+}
+
+// channelStopAttributes are the attributes that can only be updated while the channel is stopped.
+var channelStopAttributes = []string{"outputs", "filler_slate"}
+
+// checkChannelStopForUpdate fails the plan when updating a running channel requires to stop it, unless the
+// allow_stop_for_update argument is set or the channel is stopped by the same update.
+func checkChannelStopForUpdate(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || !diff.HasChanges(channelStopAttributes...) || diff.Get("allow_stop_for_update").(bool) {
+		return nil
+	}
+	if oldState, newState := diff.GetChange("channel_state"); oldState.(string) == mediatailor.ChannelStateRunning && newState.(string) != mediatailor.ChannelStateStopped {
+		return fmt.Errorf("the channel %s is running and must be stopped to update its outputs or filler slate, set allow_stop_for_update to true to allow it", diff.Get("name").(string))
+	}
+	return nil
+}
+
+// importChannelState imports channels like importStateWithRegion, with the default value of allow_stop_for_update,
+// which is not returned by MediaTailor. The policy is not imported, so that it does not conflict with an
+// awsmt_channel_policy resource managing it.
+func importChannelState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("allow_stop_for_update", false); err != nil {
+		return nil, fmt.Errorf("error while setting allow_stop_for_update: %w", err)
+	}
+	return importStateWithRegion(ctx, d, meta)
+}
+
+func startChannel(ctx context.Context, client mediatailoriface.MediaTailorAPI, channelName string, timeout time.Duration) error {
+	_, err := client.StartChannelWithContext(ctx, &mediatailor.StartChannelInput{
 		ChannelName: aws.String(channelName),
 	})
 	if err != nil {
 		return fmt.Errorf("error while starting the channel: %v", err)
 	}
-	return nil
+	return waitForChannelState(ctx, client, channelName, mediatailor.ChannelStateRunning, timeout)
 }
 
-func stopChannel(client *mediatailor.MediaTailor, channelName string) error {
-	_, err := client.StopChannel(&mediatailor.StopChannelInput{
+func stopChannel(ctx context.Context, client mediatailoriface.MediaTailorAPI, channelName string, timeout time.Duration) error {
+	_, err := client.StopChannelWithContext(ctx, &mediatailor.StopChannelInput{
 		ChannelName: aws.String(channelName),
 	})
 	if err != nil {
 		return fmt.Errorf("error while stopping the channel: %v", err)
 	}
-	return nil
+	return waitForChannelState(ctx, client, channelName, mediatailor.ChannelStateStopped, timeout)
 }
 
-func checkStatusAndStartChannel(client *mediatailor.MediaTailor, d *schema.ResourceData) error {
+func checkStatusAndStartChannel(ctx context.Context, client mediatailoriface.MediaTailorAPI, d *schema.ResourceData) error {
 	if v, ok := d.GetOk("channel_state"); ok && v != nil && v.(string) != "" {
 		if v.(string) == "RUNNING" {
-			if err := startChannel(client, d.Get("name").(string)); err != nil {
+			if err := startChannel(ctx, client, d.Get("name").(string), d.Timeout(schema.TimeoutCreate)); err != nil {
 				return err
 			}
 		}
@@ -1,9 +1,23 @@
 package awsmt
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/aws/aws-sdk-go/service/mediatailor/mediatailoriface"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
 )
 
 func createBaseList(fields map[string]*schema.Schema) *schema.Schema {
@@ -35,7 +49,77 @@ func createComputedList(fields map[string]*schema.Schema) *schema.Schema {
 	return s
 }
 
-func updateTags(client *mediatailor.MediaTailor, arn *string, oldTagValue, newTagValue interface{}) error {
+func optionalComputedIntBetween(min, max int) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.IntBetween(min, max),
+	}
+}
+
+// listFilter holds the optional name_regex and tags arguments of the data sources listing MediaTailor resources.
+type listFilter struct {
+	nameRegex *regexp.Regexp
+	tags      map[string]interface{}
+}
+
+func getListFilter(d *schema.ResourceData) (listFilter, error) {
+	var f listFilter
+	if v, ok := d.GetOk("name_regex"); ok {
+		re, err := regexp.Compile(v.(string))
+		if err != nil {
+			return f, fmt.Errorf("error while compiling the name regex: %v", err)
+		}
+		f.nameRegex = re
+	}
+	if v, ok := d.GetOk("tags"); ok {
+		f.tags = v.(map[string]interface{})
+	}
+	return f, nil
+}
+
+func (f listFilter) matches(name *string, tags map[string]*string) bool {
+	if f.nameRegex != nil && !f.nameRegex.MatchString(aws.StringValue(name)) {
+		return false
+	}
+	for k, v := range f.tags {
+		if value, ok := tags[k]; !ok || aws.StringValue(value) != v.(string) {
+			return false
+		}
+	}
+	return true
+}
+
+func setListValues(d *schema.ResourceData, values map[string]interface{}) error {
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("error while setting the %s: %w", k, err)
+		}
+	}
+	return nil
+}
+
+func flattenTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.String()
+}
+
+func suppressEquivalentTimestamps(_, old, new string, _ *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
+
+func updateTags(ctx context.Context, client mediatailoriface.MediaTailorAPI, arn *string, oldTagValue, newTagValue interface{}) error {
 
 	var removedTags []string
 	for k := range oldTagValue.(map[string]interface{}) {
@@ -44,19 +128,14 @@ func updateTags(client *mediatailor.MediaTailor, arn *string, oldTagValue, newTa
 		}
 	}
 
-	err := deleteTags(client, aws.StringValue(arn), removedTags)
+	err := deleteTags(ctx, client, aws.StringValue(arn), removedTags)
 	if err != nil {
 		return err
 	}
 
-	if newTagValue != nil {
-		var newTags = make(map[string]*string)
-		for k, v := range newTagValue.(map[string]interface{}) {
-			val := v.(string)
-			newTags[k] = &val
-		}
-		tagInput := mediatailor.TagResourceInput{ResourceArn: arn, Tags: newTags}
-		_, err := client.TagResource(&tagInput)
+	if newTagValue != nil && len(newTagValue.(map[string]interface{})) > 0 {
+		tagInput := mediatailor.TagResourceInput{ResourceArn: arn, Tags: getTagsInput(newTagValue.(map[string]interface{}))}
+		_, err := client.TagResourceWithContext(ctx, &tagInput)
 		if err != nil {
 			return err
 		}
@@ -64,7 +143,105 @@ func updateTags(client *mediatailor.MediaTailor, arn *string, oldTagValue, newTa
 	return nil
 }
 
-func deleteTags(client *mediatailor.MediaTailor, resourceArn string, removedTags []string) error {
+// @ADR
+// Context: The provider supports default tags, which have to be applied to every resource alongside the tags of the
+// resource, without showing up as a diff on the tags attribute.
+// Decision: We decided to follow the hashicorp/aws provider: the tags attribute only contains the tags of the
+// resource, while the computed tags_all attribute contains the tags of the resource merged with the default tags.
+// Tags are always sent to MediaTailor from tags_all.
+// Consequences: Every resource with tags needs the tags_all attribute and the setTagsDiff CustomizeDiff function.
+// A tag declared both on the resource and in the default tags takes the value of the resource.
+// Update: Tags matching the provider ignore_tags block are left out of both tags and tags_all, so that tags added
+// outside Terraform are neither shown as a diff nor removed.
+
+// mergeTags returns the default tags of the provider overridden by the tags of the resource, without the ignored tags.
+func mergeTags(meta interface{}, tags map[string]interface{}) map[string]interface{} {
+	allTags := map[string]interface{}{}
+	for k, v := range meta.(*providerMeta).defaultTags {
+		allTags[k] = v
+	}
+	for k, v := range tags {
+		allTags[k] = v
+	}
+	return removeIgnoredTags(meta, allTags)
+}
+
+func removeIgnoredTags(meta interface{}, tags map[string]interface{}) map[string]interface{} {
+	ignoreTags := meta.(*providerMeta).ignoreTags
+	output := map[string]interface{}{}
+	for k, v := range tags {
+		if !ignoreTags.ignores(k) {
+			output[k] = v
+		}
+	}
+	return output
+}
+
+func getTagsInput(tags map[string]interface{}) map[string]*string {
+	outputMap := make(map[string]*string)
+	for k, value := range tags {
+		temp := value.(string)
+		outputMap[k] = &temp
+	}
+	return outputMap
+}
+
+// getTagsAll returns the tags to send to MediaTailor for the resource, default tags included.
+func getTagsAll(d *schema.ResourceData, meta interface{}) map[string]*string {
+	return getTagsInput(mergeTags(meta, d.Get("tags").(map[string]interface{})))
+}
+
+// updateTagsAll updates the tags of the resource, default tags included, if either changed.
+func updateTagsAll(ctx context.Context, client mediatailoriface.MediaTailorAPI, arn *string, d *schema.ResourceData, meta interface{}) error {
+	oldValue, _ := d.GetChange("tags_all")
+	oldValue = removeIgnoredTags(meta, oldValue.(map[string]interface{}))
+	newValue := mergeTags(meta, d.Get("tags").(map[string]interface{}))
+	if reflect.DeepEqual(oldValue, newValue) {
+		return nil
+	}
+	return updateTags(ctx, client, arn, oldValue, newValue)
+}
+
+// setTagsDiff plans the tags_all attribute from the tags of the resource and the default tags of the provider.
+func setTagsDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("tags") {
+		return diff.SetNewComputed("tags_all")
+	}
+	allTags := mergeTags(meta, diff.Get("tags").(map[string]interface{}))
+	if !reflect.DeepEqual(allTags, diff.Get("tags_all").(map[string]interface{})) {
+		return diff.SetNew("tags_all", allTags)
+	}
+	return nil
+}
+
+// setTagsAndTagsAll sets the tags_all attribute to the tags read from MediaTailor without the ignored ones, and the tags
+// attribute to the same tags without the default ones, unless they are also declared on the resource.
+func setTagsAndTagsAll(d *schema.ResourceData, meta interface{}, tags map[string]*string) diag.Diagnostics {
+	defaultTags := meta.(*providerMeta).defaultTags
+	ignoreTags := meta.(*providerMeta).ignoreTags
+	configuredTags := d.Get("tags").(map[string]interface{})
+	resourceTags := map[string]interface{}{}
+	allTags := map[string]interface{}{}
+	for k, v := range tags {
+		if ignoreTags.ignores(k) {
+			continue
+		}
+		value := aws.StringValue(v)
+		allTags[k] = value
+		if defaultValue, ok := defaultTags[k]; ok && defaultValue == value {
+			if _, ok := configuredTags[k]; !ok {
+				continue
+			}
+		}
+		resourceTags[k] = value
+	}
+	return diagnosticsFromErrors([]error{
+		setAttribute(d, "tags", resourceTags),
+		setAttribute(d, "tags_all", allTags),
+	})
+}
+
+func deleteTags(ctx context.Context, client mediatailoriface.MediaTailorAPI, resourceArn string, removedTags []string) error {
 	if len(removedTags) != 0 {
 
 		var removedValuesPointer []*string
@@ -73,7 +250,7 @@ func deleteTags(client *mediatailor.MediaTailor, resourceArn string, removedTags
 		}
 
 		untagInput := mediatailor.UntagResourceInput{ResourceArn: aws.String(resourceArn), TagKeys: removedValuesPointer}
-		_, err := client.UntagResource(&untagInput)
+		_, err := client.UntagResourceWithContext(ctx, &untagInput)
 		if err != nil {
 			return err
 		}
@@ -81,6 +258,108 @@ func deleteTags(client *mediatailor.MediaTailor, resourceArn string, removedTags
 	return nil
 }
 
+// @ADR
+// Context: Several Read functions built diagnostics and dropped them, and the set functions only returned the first
+// error, so a value that could not be stored silently corrupted the state.
+// Decision: We decided to set every attribute with setAttribute, which records the attribute of the error, and to
+// convert all the errors of a set function into diagnostics with diagnosticsFromErrors.
+// Consequences: Every value that cannot be set is reported, with its attribute path, instead of the first one only.
+
+// attributeError is the error returned when a value cannot be set in an attribute of the resource.
+type attributeError struct {
+	key string
+	err error
+}
+
+func (e attributeError) Error() string {
+	return fmt.Sprintf("error while setting the %s attribute: %v", e.key, e.err)
+}
+
+func (e attributeError) Unwrap() error {
+	return e.err
+}
+
+// setAttribute sets the value of the attribute, and returns an attributeError if it cannot be set.
+func setAttribute(d *schema.ResourceData, key string, value interface{}) error {
+	if err := d.Set(key, value); err != nil {
+		return attributeError{key: key, err: err}
+	}
+	return nil
+}
+
+// diagnosticsFromErrors returns a diagnostic for every non nil error, with the attribute path of the attributeErrors.
+func diagnosticsFromErrors(errs []error) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		d := diag.Diagnostic{Severity: diag.Error, Summary: err.Error()}
+		var attrErr attributeError
+		if errors.As(err, &attrErr) {
+			d.Summary = fmt.Sprintf("error while setting the %s attribute", attrErr.key)
+			d.Detail = attrErr.err.Error()
+			d.AttributePath = cty.GetAttrPath(attrErr.key)
+		}
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// errCodeNotFound is the error code returned by MediaTailor for missing resources, which is not declared in the SDK.
+const errCodeNotFound = "NotFoundException"
+
+// isNotFound returns whether the error is a MediaTailor error reporting a missing resource.
+func isNotFound(err error) bool {
+	var requestErr awserr.RequestFailure
+	if errors.As(err, &requestErr) && requestErr.StatusCode() == http.StatusNotFound {
+		return true
+	}
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == errCodeNotFound
+}
+
+// @ADR
+// Context: Identical playback setups run in several regions, which required one aliased provider per region.
+// Decision: We decided to add an optional region argument to every resource and data source, routed to a MediaTailor
+// client per region cached in the provider meta. The region is accepted as a suffix of the import IDs, separated by an
+// @, and is taken from the ID itself when the ID is an ARN.
+// Consequences: The IDs in the state do not contain the region, so that the existing states do not need to be migrated.
+
+// getClient returns the MediaTailor client of the region of the resource.
+func getClient(d *schema.ResourceData, meta interface{}) mediatailoriface.MediaTailorAPI {
+	return meta.(*providerMeta).clientForRegion(d.Get("region").(string))
+}
+
+// setRegion stores the region of the resource, defaulting to the region of the provider configuration.
+func setRegion(d *schema.ResourceData, meta interface{}) error {
+	region := d.Get("region").(string)
+	if region == "" {
+		region = meta.(*providerMeta).region
+	}
+	if err := d.Set("region", region); err != nil {
+		return fmt.Errorf("error while setting the region: %w", err)
+	}
+	return nil
+}
+
+// importStateWithRegion imports resources identified by an ID, optionally followed by @ and the region of the resource,
+// for example playback_configuration_name@us-east-1. If the ID is an ARN, the region of the ARN is used.
+func importStateWithRegion(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	if i := strings.LastIndex(id, "@"); i > 0 {
+		if err := d.Set("region", id[i+1:]); err != nil {
+			return nil, fmt.Errorf("error while setting the region: %w", err)
+		}
+		d.SetId(id[:i])
+	} else if resourceArn, err := arn.Parse(id); err == nil {
+		if err := d.Set("region", resourceArn.Region); err != nil {
+			return nil, fmt.Errorf("error while setting the region: %w", err)
+		}
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
 func getHttpPackageConfigurations(d *schema.ResourceData) []*mediatailor.HttpPackageConfiguration {
 	if v, ok := d.GetOk("http_package_configurations"); ok && v.([]interface{})[0] != nil {
 		configurations := v.([]interface{})
@@ -0,0 +1,257 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/aws/aws-sdk-go/service/mediatailor/mediatailoriface"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestListFilterMatches(t *testing.T) {
+	tags := map[string]*string{"Environment": aws.String("dev"), "Team": aws.String("video")}
+	cases := []struct {
+		name     string
+		filter   listFilter
+		expected bool
+	}{
+		{"empty filter", listFilter{}, true},
+		{"matching regex", listFilter{nameRegex: regexp.MustCompile("^test_")}, true},
+		{"non matching regex", listFilter{nameRegex: regexp.MustCompile("^prod_")}, false},
+		{"matching tags", listFilter{tags: map[string]interface{}{"Environment": "dev"}}, true},
+		{"different tag value", listFilter{tags: map[string]interface{}{"Environment": "prod"}}, false},
+		{"missing tag", listFilter{tags: map[string]interface{}{"Owner": "me"}}, false},
+	}
+	for _, c := range cases {
+		if actual := c.filter.matches(aws.String("test_channel"), tags); actual != c.expected {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	meta := &providerMeta{defaultTags: map[string]interface{}{"Environment": "dev", "Team": "video"}}
+	expected := map[string]interface{}{"Environment": "prod", "Team": "video", "Name": "test"}
+
+	output := mergeTags(meta, map[string]interface{}{"Environment": "prod", "Name": "test"})
+
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("expected %v, got %v", expected, output)
+	}
+}
+
+func TestSetTagsAndTagsAll(t *testing.T) {
+	meta := &providerMeta{defaultTags: map[string]interface{}{"Environment": "dev", "Team": "video"}}
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{"tags": &optionalTags, "tags_all": &computedTags}, map[string]interface{}{
+		"tags": map[string]interface{}{"Team": "video", "Name": "test"},
+	})
+	tags := map[string]*string{"Environment": aws.String("dev"), "Team": aws.String("video"), "Name": aws.String("test"), "Owner": aws.String("me")}
+
+	if diags := setTagsAndTagsAll(d, meta, tags); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	// the default tag is only hidden from tags if it is not declared on the resource
+	expectedTags := map[string]interface{}{"Team": "video", "Name": "test", "Owner": "me"}
+	if output := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(output, expectedTags) {
+		t.Errorf("expected tags %v, got %v", expectedTags, output)
+	}
+	expectedTagsAll := map[string]interface{}{"Environment": "dev", "Team": "video", "Name": "test", "Owner": "me"}
+	if output := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(output, expectedTagsAll) {
+		t.Errorf("expected tags_all %v, got %v", expectedTagsAll, output)
+	}
+}
+
+func TestSetTagsAndTagsAll_error(t *testing.T) {
+	// arrange
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{"tags": &optionalTags, "tags_all": &computedString}, map[string]interface{}{})
+
+	// act
+	diags := setTagsAndTagsAll(d, &providerMeta{}, map[string]*string{"Name": aws.String("test")})
+
+	// assert
+	if len(diags) != 1 || !reflect.DeepEqual(diags[0].AttributePath, cty.GetAttrPath("tags_all")) {
+		t.Errorf("expected a diagnostic with the tags_all attribute path, got %v", diags)
+	}
+}
+
+func TestUpdateTags(t *testing.T) {
+	cases := map[string]struct {
+		oldTags  map[string]interface{}
+		newTags  map[string]interface{}
+		expected []string
+	}{
+		"added tag":   {map[string]interface{}{}, map[string]interface{}{"Team": "video"}, []string{"TagResource"}},
+		"removed tag": {map[string]interface{}{"Team": "video"}, map[string]interface{}{}, []string{"UntagResource"}},
+		"changed tags": {
+			map[string]interface{}{"Team": "video", "Owner": "me"},
+			map[string]interface{}{"Team": "audio"},
+			[]string{"UntagResource", "TagResource"},
+		},
+	}
+
+	for name, c := range cases {
+		// arrange
+		client := newMockMediaTailor("")
+
+		// act
+		err := updateTags(context.Background(), client, aws.String("arn"), c.oldTags, c.newTags)
+
+		// assert
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+		if !reflect.DeepEqual(client.calls, c.expected) {
+			t.Errorf("%s: expected the calls %v, got %v", name, c.expected, client.calls)
+		}
+	}
+}
+
+func TestIgnoreTags(t *testing.T) {
+	meta := &providerMeta{
+		defaultTags: map[string]interface{}{"billing:default": "true"},
+		ignoreTags:  ignoreTagsConfig{keys: []string{"external"}, keyPrefixes: []string{"billing:"}},
+	}
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{"tags": &optionalTags, "tags_all": &computedTags}, map[string]interface{}{
+		"tags": map[string]interface{}{"Name": "test"},
+	})
+	tags := map[string]*string{"Name": aws.String("test"), "external": aws.String("true"), "billing:team": aws.String("video")}
+
+	if output := mergeTags(meta, map[string]interface{}{"Name": "test", "external": "false"}); !reflect.DeepEqual(output, map[string]interface{}{"Name": "test"}) {
+		t.Errorf("expected the ignored tags to be removed from the merged tags, got %v", output)
+	}
+	if diags := setTagsAndTagsAll(d, meta, tags); diags.HasError() {
+		t.Fatal(diags)
+	}
+	expected := map[string]interface{}{"Name": "test"}
+	if output := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(output, expected) {
+		t.Errorf("expected tags %v, got %v", expected, output)
+	}
+	if output := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(output, expected) {
+		t.Errorf("expected tags_all %v, got %v", expected, output)
+	}
+}
+
+func TestImportStateWithRegion(t *testing.T) {
+	cases := map[string]struct {
+		id             string
+		expectedId     string
+		expectedRegion string
+	}{
+		"name":            {id: "example", expectedId: "example", expectedRegion: ""},
+		"name and region": {id: "example@us-east-1", expectedId: "example", expectedRegion: "us-east-1"},
+		"composite id":    {id: "source_location/vod_source@us-east-1", expectedId: "source_location/vod_source", expectedRegion: "us-east-1"},
+		"arn":             {id: "arn:aws:mediatailor:us-east-1:123456789012:channel/example", expectedId: "arn:aws:mediatailor:us-east-1:123456789012:channel/example", expectedRegion: "us-east-1"},
+		"arn and region":  {id: "arn:aws:mediatailor:us-east-1:123456789012:channel/example@eu-west-1", expectedId: "arn:aws:mediatailor:us-east-1:123456789012:channel/example", expectedRegion: "eu-west-1"},
+	}
+	for name, c := range cases {
+		// arrange
+		d := resourcePlaybackConfiguration().TestResourceData()
+		d.SetId(c.id)
+
+		// act
+		res, err := importStateWithRegion(context.Background(), d, nil)
+
+		// assert
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if res[0].Id() != c.expectedId || res[0].Get("region").(string) != c.expectedRegion {
+			t.Errorf("%s: expected %s in %s, got %s in %s", name, c.expectedId, c.expectedRegion, res[0].Id(), res[0].Get("region"))
+		}
+	}
+}
+
+func TestClientForRegion(t *testing.T) {
+	// arrange
+	sess := session.Must(session.NewSession())
+	newClient := func(region string) mediatailoriface.MediaTailorAPI {
+		return mediatailor.New(sess, aws.NewConfig().WithRegion(region))
+	}
+	meta := &providerMeta{client: newClient("eu-central-1"), region: "eu-central-1", newClient: newClient}
+
+	// act
+	defaultClient := meta.clientForRegion("")
+	sameRegionClient := meta.clientForRegion("eu-central-1")
+	otherRegionClient := meta.clientForRegion("us-east-1")
+
+	// assert
+	if defaultClient != meta.client || sameRegionClient != meta.client {
+		t.Errorf("expected the client of the provider region")
+	}
+	if aws.StringValue(otherRegionClient.(*mediatailor.MediaTailor).Config.Region) != "us-east-1" {
+		t.Errorf("expected a client in us-east-1, got %s", aws.StringValue(otherRegionClient.(*mediatailor.MediaTailor).Config.Region))
+	}
+	if meta.clientForRegion("us-east-1") != otherRegionClient {
+		t.Errorf("expected the client of us-east-1 to be cached")
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	cases := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"not found error code":  {err: awserr.New(errCodeNotFound, "not found", nil), expected: true},
+		"not found status code": {err: awserr.NewRequestFailure(awserr.New("UnknownError", "not found", nil), http.StatusNotFound, "id"), expected: true},
+		"wrapped not found":     {err: fmt.Errorf("error while reading: %w", awserr.New(errCodeNotFound, "not found", nil)), expected: true},
+		"bad request":           {err: awserr.NewRequestFailure(awserr.New(mediatailor.ErrCodeBadRequestException, "NotFound", nil), http.StatusBadRequest, "id"), expected: false},
+		"error containing text": {err: fmt.Errorf("NotFoundException"), expected: false},
+		"no error":              {err: nil, expected: false},
+	}
+	for name, c := range cases {
+		if output := isNotFound(c.err); output != c.expected {
+			t.Errorf("%s: expected %v, got %v", name, c.expected, output)
+		}
+	}
+}
+
+func TestDiagnosticsFromErrors(t *testing.T) {
+	// arrange
+	d := resourceChannel().TestResourceData()
+	errs := []error{
+		setAttribute(d, "name", "example"),
+		setAttribute(d, "playback_mode", map[string]interface{}{"invalid": "type"}),
+		fmt.Errorf("error while setting the outputs: %w", setAttribute(d, "outputs", "invalid type")),
+		fmt.Errorf("unexpected error"),
+	}
+
+	// act
+	diags := diagnosticsFromErrors(errs)
+
+	// assert
+	if len(diags) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %v", diags)
+	}
+	for i, expected := range []cty.Path{cty.GetAttrPath("playback_mode"), cty.GetAttrPath("outputs"), nil} {
+		if !reflect.DeepEqual(diags[i].AttributePath, expected) {
+			t.Errorf("expected the attribute path %v, got %v", expected, diags[i].AttributePath)
+		}
+	}
+}
+
+func TestReturnPlaybackConfiguration(t *testing.T) {
+	d := resourcePlaybackConfiguration().TestResourceData()
+	values := map[string]interface{}{
+		"ad_decision_server_url":   aws.String("https://exampleurl.com/"),
+		"bumper":                   "invalid type",
+		"video_content_source_url": []interface{}{"invalid type"},
+	}
+
+	diags := returnPlaybackConfiguration(d, values)
+
+	if len(diags) != 2 || !reflect.DeepEqual(diags[0].AttributePath, cty.GetAttrPath("bumper")) {
+		t.Errorf("expected a diagnostic for every attribute that could not be set, got %v", diags)
+	}
+	if d.Get("ad_decision_server_url").(string) != "https://exampleurl.com/" {
+		t.Errorf("expected the valid attributes to be set")
+	}
+}
@@ -1,38 +1,32 @@
 package awsmt
 
 import (
-	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func setLiveSource(values *mediatailor.DescribeLiveSourceOutput, d *schema.ResourceData) error {
+func setLiveSource(values *mediatailor.DescribeLiveSourceOutput, d *schema.ResourceData) diag.Diagnostics {
 	var errors []error
 
 	if values.Arn != nil {
-		errors = append(errors, d.Set("arn", values.Arn))
+		errors = append(errors, setAttribute(d, "arn", values.Arn))
 	}
 	if values.CreationTime != nil {
-		errors = append(errors, d.Set("creation_time", values.CreationTime.String()))
+		errors = append(errors, setAttribute(d, "creation_time", values.CreationTime.String()))
 	}
 	errors = append(errors, setHttpPackageConfigurations(values.HttpPackageConfigurations, d))
 	if values.LastModifiedTime != nil {
-		errors = append(errors, d.Set("last_modified_time", values.LastModifiedTime.String()))
+		errors = append(errors, setAttribute(d, "last_modified_time", values.LastModifiedTime.String()))
 	}
 	if values.LiveSourceName != nil {
-		errors = append(errors, d.Set("name", values.LiveSourceName))
+		errors = append(errors, setAttribute(d, "name", values.LiveSourceName))
 	}
 	if values.SourceLocationName != nil {
-		errors = append(errors, d.Set("source_location_name", values.SourceLocationName))
+		errors = append(errors, setAttribute(d, "source_location_name", values.SourceLocationName))
 	}
-	errors = append(errors, d.Set("tags", values.Tags))
-	for _, e := range errors {
-		if e != nil {
-			return fmt.Errorf("the following error occured while setting the values: %w", e)
-		}
-	}
-	return nil
+	return diagnosticsFromErrors(errors)
 }
 
 func getCreateLiveSourceInput(d *schema.ResourceData) mediatailor.CreateLiveSourceInput {
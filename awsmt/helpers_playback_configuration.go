@@ -1,13 +1,16 @@
 package awsmt
 
 import (
+	"context"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/aws/aws-sdk-go/service/mediatailor/mediatailoriface"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"sort"
 )
 
-func getSinglePlaybackConfiguration(c *mediatailor.MediaTailor, name string) (*mediatailor.PlaybackConfiguration, error) {
-	output, err := c.GetPlaybackConfiguration(&mediatailor.GetPlaybackConfigurationInput{Name: &name})
+func getSinglePlaybackConfiguration(ctx context.Context, c mediatailoriface.MediaTailorAPI, name string) (*mediatailor.PlaybackConfiguration, error) {
+	output, err := c.GetPlaybackConfigurationWithContext(ctx, &mediatailor.GetPlaybackConfigurationInput{Name: &name})
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +80,6 @@ func flattenPlaybackConfiguration(c *mediatailor.PlaybackConfiguration) map[stri
 	output["playback_endpoint_prefix"] = c.PlaybackEndpointPrefix
 	output["session_initialization_endpoint_prefix"] = c.SessionInitializationEndpointPrefix
 	output["slate_ad_url"] = c.SlateAdUrl
-	output["tags"] = c.Tags
 	output["transcode_profile_name"] = c.TranscodeProfileName
 	output["video_content_source_url"] = c.VideoContentSourceUrl
 	return output
@@ -270,23 +272,23 @@ func getPlaybackConfigurationInput(d *schema.ResourceData) mediatailor.PutPlayba
 	return input
 }
 
-func returnPlaybackConfiguration(d *schema.ResourceData, values map[string]interface{}, diags diag.Diagnostics) diag.Diagnostics {
+func returnPlaybackConfiguration(d *schema.ResourceData, values map[string]interface{}) diag.Diagnostics {
+	keys := make([]string, 0, len(values))
 	for k := range values {
-		setSingleValue(d, values, diags, k)
+		keys = append(keys, k)
 	}
-	return diags
-}
+	// the keys are sorted so that the diagnostics are always returned in the same order
+	sort.Strings(keys)
 
-func setSingleValue(d *schema.ResourceData, values map[string]interface{}, diags diag.Diagnostics, name string) diag.Diagnostics {
-	err := d.Set(name, values[name])
-	if err != nil {
-		return diag.FromErr(err)
+	var errors []error
+	for _, k := range keys {
+		errors = append(errors, setAttribute(d, k, values[k]))
 	}
-	return diags
+	return diagnosticsFromErrors(errors)
 }
 
-func deletePlaybackConfiguration(client *mediatailor.MediaTailor, name string) diag.Diagnostics {
-	_, err := client.DeletePlaybackConfiguration(&mediatailor.DeletePlaybackConfigurationInput{Name: &name})
+func deletePlaybackConfiguration(ctx context.Context, client mediatailoriface.MediaTailorAPI, name string) diag.Diagnostics {
+	_, err := client.DeletePlaybackConfigurationWithContext(ctx, &mediatailor.DeletePlaybackConfigurationInput{Name: &name})
 	if err != nil {
 		return diag.FromErr(err)
 	}
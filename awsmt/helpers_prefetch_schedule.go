@@ -0,0 +1,171 @@
+package awsmt
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"time"
+)
+
+func getTimestamp(values map[string]interface{}, key string) (*time.Time, error) {
+	if str, ok := values[key]; ok && str.(string) != "" {
+		t, err := time.Parse(time.RFC3339, str.(string))
+		if err != nil {
+			return nil, fmt.Errorf("error while parsing %s: %v", key, err)
+		}
+		return &t, nil
+	}
+	return nil, nil
+}
+
+func getPrefetchConsumption(d *schema.ResourceData) (*mediatailor.PrefetchConsumption, error) {
+	if v, ok := d.GetOk("consumption"); ok && v.([]interface{})[0] != nil {
+		val := v.([]interface{})[0].(map[string]interface{})
+		temp := mediatailor.PrefetchConsumption{}
+
+		if criteria, ok := val["avail_matching_criteria"]; ok {
+			for _, c := range criteria.([]interface{}) {
+				current := c.(map[string]interface{})
+				temp.AvailMatchingCriteria = append(temp.AvailMatchingCriteria, &mediatailor.AvailMatchingCriteria{
+					DynamicVariable: aws.String(current["dynamic_variable"].(string)),
+					Operator:        aws.String(current["operator"].(string)),
+				})
+			}
+		}
+
+		endTime, err := getTimestamp(val, "end_time")
+		if err != nil {
+			return nil, err
+		}
+		temp.EndTime = endTime
+
+		startTime, err := getTimestamp(val, "start_time")
+		if err != nil {
+			return nil, err
+		}
+		temp.StartTime = startTime
+
+		return &temp, nil
+	}
+	return nil, nil
+}
+
+func getPrefetchRetrieval(d *schema.ResourceData) (*mediatailor.PrefetchRetrieval, error) {
+	if v, ok := d.GetOk("retrieval"); ok && v.([]interface{})[0] != nil {
+		val := v.([]interface{})[0].(map[string]interface{})
+		temp := mediatailor.PrefetchRetrieval{}
+
+		if variables, ok := val["dynamic_variables"]; ok && len(variables.(map[string]interface{})) > 0 {
+			outputMap := make(map[string]*string)
+			for k, value := range variables.(map[string]interface{}) {
+				str := value.(string)
+				outputMap[k] = &str
+			}
+			temp.DynamicVariables = outputMap
+		}
+
+		endTime, err := getTimestamp(val, "end_time")
+		if err != nil {
+			return nil, err
+		}
+		temp.EndTime = endTime
+
+		startTime, err := getTimestamp(val, "start_time")
+		if err != nil {
+			return nil, err
+		}
+		temp.StartTime = startTime
+
+		return &temp, nil
+	}
+	return nil, nil
+}
+
+func getCreatePrefetchScheduleInput(d *schema.ResourceData) (mediatailor.CreatePrefetchScheduleInput, error) {
+	var params mediatailor.CreatePrefetchScheduleInput
+
+	consumption, err := getPrefetchConsumption(d)
+	if err != nil {
+		return params, err
+	}
+	params.Consumption = consumption
+
+	if v, ok := d.GetOk("name"); ok {
+		params.Name = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("playback_configuration_name"); ok {
+		params.PlaybackConfigurationName = aws.String(v.(string))
+	}
+
+	retrieval, err := getPrefetchRetrieval(d)
+	if err != nil {
+		return params, err
+	}
+	params.Retrieval = retrieval
+
+	if v, ok := d.GetOk("stream_id"); ok {
+		params.StreamId = aws.String(v.(string))
+	}
+
+	return params, nil
+}
+
+func formatTimestamp(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func setPrefetchConsumption(values *mediatailor.PrefetchConsumption, d *schema.ResourceData) error {
+	if values == nil {
+		return nil
+	}
+	var criteria []interface{}
+	for _, c := range values.AvailMatchingCriteria {
+		criteria = append(criteria, map[string]interface{}{
+			"dynamic_variable": c.DynamicVariable,
+			"operator":         c.Operator,
+		})
+	}
+	temp := map[string]interface{}{
+		"avail_matching_criteria": criteria,
+		"end_time":                formatTimestamp(values.EndTime),
+		"start_time":              formatTimestamp(values.StartTime),
+	}
+	if err := setAttribute(d, "consumption", []interface{}{temp}); err != nil {
+		return fmt.Errorf("error while setting the consumption: %w", err)
+	}
+	return nil
+}
+
+func setPrefetchRetrieval(values *mediatailor.PrefetchRetrieval, d *schema.ResourceData) error {
+	if values == nil {
+		return nil
+	}
+	temp := map[string]interface{}{
+		"dynamic_variables": values.DynamicVariables,
+		"end_time":          formatTimestamp(values.EndTime),
+		"start_time":        formatTimestamp(values.StartTime),
+	}
+	if err := setAttribute(d, "retrieval", []interface{}{temp}); err != nil {
+		return fmt.Errorf("error while setting the retrieval: %w", err)
+	}
+	return nil
+}
+
+func setPrefetchSchedule(values *mediatailor.GetPrefetchScheduleOutput, d *schema.ResourceData) diag.Diagnostics {
+	var errors []error
+
+	errors = append(errors, setAttribute(d, "arn", values.Arn))
+	errors = append(errors, setPrefetchConsumption(values.Consumption, d))
+	errors = append(errors, setAttribute(d, "name", values.Name))
+	errors = append(errors, setAttribute(d, "playback_configuration_name", values.PlaybackConfigurationName))
+	errors = append(errors, setPrefetchRetrieval(values.Retrieval, d))
+	errors = append(errors, setAttribute(d, "stream_id", values.StreamId))
+
+	return diagnosticsFromErrors(errors)
+}
@@ -0,0 +1,349 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func getSlateSource(values []interface{}) *mediatailor.SlateSource {
+	if len(values) > 0 && values[0] != nil {
+		val := values[0].(map[string]interface{})
+		temp := mediatailor.SlateSource{}
+		if str, ok := val["source_location_name"]; ok && str.(string) != "" {
+			temp.SourceLocationName = aws.String(str.(string))
+		}
+		if str, ok := val["vod_source_name"]; ok && str.(string) != "" {
+			temp.VodSourceName = aws.String(str.(string))
+		}
+		return &temp
+	}
+	return nil
+}
+
+// getRawBlock returns the raw configuration of the element at the given index of a nested block list, or a null value
+// if it is not available.
+func getRawBlock(raw cty.Value, name string, index int) cty.Value {
+	if !raw.IsKnown() || raw.IsNull() || !raw.Type().IsObjectType() || !raw.Type().HasAttribute(name) {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	list := raw.GetAttr(name)
+	if !list.IsKnown() || list.IsNull() || !list.CanIterateElements() || list.LengthInt() <= index {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	return list.Index(cty.NumberIntVal(int64(index)))
+}
+
+// getConfiguredInt returns the integer attribute of a nested block, or nil if it is not set. The ResourceData reads 0
+// for unset attributes, so the raw configuration of the block is used to tell them apart from an explicit 0, which is
+// a meaningful SCTE-35 value. Without raw configuration, 0 is considered unset.
+func getConfiguredInt(values map[string]interface{}, raw cty.Value, name string) *int64 {
+	num, ok := values[name]
+	if !ok {
+		return nil
+	}
+	configured := raw.IsKnown() && !raw.IsNull() && raw.Type().IsObjectType() && raw.Type().HasAttribute(name) && !raw.GetAttr(name).IsNull()
+	if !configured && num.(int) == 0 {
+		return nil
+	}
+	return aws.Int64(int64(num.(int)))
+}
+
+func getSpliceInsertMessage(values []interface{}, raw cty.Value) *mediatailor.SpliceInsertMessage {
+	if len(values) > 0 && values[0] != nil {
+		val := values[0].(map[string]interface{})
+		rawMessage := getRawBlock(raw, "splice_insert_message", 0)
+		return &mediatailor.SpliceInsertMessage{
+			AvailNum:        getConfiguredInt(val, rawMessage, "avail_num"),
+			AvailsExpected:  getConfiguredInt(val, rawMessage, "avails_expected"),
+			SpliceEventId:   getConfiguredInt(val, rawMessage, "splice_event_id"),
+			UniqueProgramId: getConfiguredInt(val, rawMessage, "unique_program_id"),
+		}
+	}
+	return nil
+}
+
+func getSegmentationDescriptor(descriptor interface{}, raw cty.Value) *mediatailor.SegmentationDescriptor {
+	current := descriptor.(map[string]interface{})
+	temp := mediatailor.SegmentationDescriptor{
+		SegmentNum:           getConfiguredInt(current, raw, "segment_num"),
+		SegmentationEventId:  getConfiguredInt(current, raw, "segmentation_event_id"),
+		SegmentationTypeId:   getConfiguredInt(current, raw, "segmentation_type_id"),
+		SegmentationUpidType: getConfiguredInt(current, raw, "segmentation_upid_type"),
+		SegmentsExpected:     getConfiguredInt(current, raw, "segments_expected"),
+		SubSegmentNum:        getConfiguredInt(current, raw, "sub_segment_num"),
+		SubSegmentsExpected:  getConfiguredInt(current, raw, "sub_segments_expected"),
+	}
+	if str, ok := current["segmentation_upid"]; ok && str.(string) != "" {
+		temp.SegmentationUpid = aws.String(str.(string))
+	}
+	return &temp
+}
+
+func getTimeSignalMessage(values []interface{}, raw cty.Value) *mediatailor.TimeSignalMessage {
+	if len(values) > 0 && values[0] != nil {
+		val := values[0].(map[string]interface{})
+		rawMessage := getRawBlock(raw, "time_signal_message", 0)
+		temp := mediatailor.TimeSignalMessage{}
+		if v, ok := val["segmentation_descriptors"]; ok {
+			for i, descriptor := range v.([]interface{}) {
+				temp.SegmentationDescriptors = append(temp.SegmentationDescriptors, getSegmentationDescriptor(descriptor, getRawBlock(rawMessage, "segmentation_descriptors", i)))
+			}
+		}
+		return &temp
+	}
+	return nil
+}
+
+func getAdBreak(adBreak interface{}, raw cty.Value) *mediatailor.AdBreak {
+	current := adBreak.(map[string]interface{})
+	temp := mediatailor.AdBreak{}
+
+	if str, ok := current["message_type"]; ok && str.(string) != "" {
+		temp.MessageType = aws.String(str.(string))
+	}
+	if num, ok := current["offset_millis"]; ok {
+		temp.OffsetMillis = aws.Int64(int64(num.(int)))
+	}
+	if v, ok := current["slate"]; ok {
+		temp.Slate = getSlateSource(v.([]interface{}))
+	}
+	if v, ok := current["splice_insert_message"]; ok {
+		temp.SpliceInsertMessage = getSpliceInsertMessage(v.([]interface{}), raw)
+	}
+	if v, ok := current["time_signal_message"]; ok {
+		temp.TimeSignalMessage = getTimeSignalMessage(v.([]interface{}), raw)
+	}
+	return &temp
+}
+
+func getAdBreaks(d *schema.ResourceData) []*mediatailor.AdBreak {
+	if v, ok := d.GetOk("ad_breaks"); ok && v.([]interface{})[0] != nil {
+		adBreaks := v.([]interface{})
+
+		var res []*mediatailor.AdBreak
+
+		for i, adBreak := range adBreaks {
+			res = append(res, getAdBreak(adBreak, getRawBlock(d.GetRawConfig(), "ad_breaks", i)))
+		}
+		return res
+	}
+	return nil
+}
+
+func getTransition(d *schema.ResourceData) *mediatailor.Transition {
+	if v, ok := d.GetOk("schedule_configuration"); ok && v.([]interface{})[0] != nil {
+		val := v.([]interface{})[0].(map[string]interface{})
+		temp := mediatailor.Transition{}
+
+		if num, ok := val["duration_millis"]; ok && num.(int) != 0 {
+			temp.DurationMillis = aws.Int64(int64(num.(int)))
+		}
+		if str, ok := val["relative_position"]; ok {
+			temp.RelativePosition = aws.String(str.(string))
+		}
+		if str, ok := val["relative_program"]; ok && str.(string) != "" {
+			temp.RelativeProgram = aws.String(str.(string))
+		}
+		if num, ok := val["scheduled_start_time_millis"]; ok && num.(int) != 0 {
+			temp.ScheduledStartTimeMillis = aws.Int64(int64(num.(int)))
+		}
+		if str, ok := val["transition_type"]; ok {
+			temp.Type = aws.String(str.(string))
+		}
+		return &temp
+	}
+	return nil
+}
+
+func getCreateProgramInput(d *schema.ResourceData) mediatailor.CreateProgramInput {
+	var params mediatailor.CreateProgramInput
+
+	if a := getAdBreaks(d); a != nil {
+		params.AdBreaks = a
+	}
+
+	if v, ok := d.GetOk("channel_name"); ok {
+		params.ChannelName = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("live_source_name"); ok {
+		params.LiveSourceName = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("name"); ok {
+		params.ProgramName = aws.String(v.(string))
+	}
+
+	if t := getTransition(d); t != nil {
+		params.ScheduleConfiguration = &mediatailor.ScheduleConfiguration{Transition: t}
+	}
+
+	if v, ok := d.GetOk("source_location_name"); ok {
+		params.SourceLocationName = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("vod_source_name"); ok {
+		params.VodSourceName = aws.String(v.(string))
+	}
+
+	return params
+}
+
+func getUpdateProgramInput(d *schema.ResourceData) mediatailor.UpdateProgramInput {
+	var params mediatailor.UpdateProgramInput
+
+	// an empty list removes the ad breaks of the program, a nil one would keep them
+	params.AdBreaks = getAdBreaks(d)
+	if params.AdBreaks == nil {
+		params.AdBreaks = []*mediatailor.AdBreak{}
+	}
+
+	if v, ok := d.GetOk("channel_name"); ok {
+		params.ChannelName = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("name"); ok {
+		params.ProgramName = aws.String(v.(string))
+	}
+
+	// only the duration and the start time of the transition can be updated, the other fields force a new resource
+	temp := mediatailor.UpdateProgramTransition{}
+	if t := getTransition(d); t != nil {
+		temp.DurationMillis = t.DurationMillis
+		temp.ScheduledStartTimeMillis = t.ScheduledStartTimeMillis
+	}
+	params.ScheduleConfiguration = &mediatailor.UpdateProgramScheduleConfiguration{Transition: &temp}
+
+	return params
+}
+
+func flattenSegmentationDescriptor(s *mediatailor.SegmentationDescriptor) map[string]interface{} {
+	temp := map[string]interface{}{}
+	temp["segment_num"] = s.SegmentNum
+	temp["segmentation_event_id"] = s.SegmentationEventId
+	temp["segmentation_type_id"] = s.SegmentationTypeId
+	temp["segmentation_upid"] = s.SegmentationUpid
+	temp["segmentation_upid_type"] = s.SegmentationUpidType
+	temp["segments_expected"] = s.SegmentsExpected
+	temp["sub_segment_num"] = s.SubSegmentNum
+	temp["sub_segments_expected"] = s.SubSegmentsExpected
+	return temp
+}
+
+func flattenAdBreak(a *mediatailor.AdBreak) map[string]interface{} {
+	temp := map[string]interface{}{}
+	temp["message_type"] = a.MessageType
+	temp["offset_millis"] = a.OffsetMillis
+
+	if a.Slate != nil && (a.Slate.SourceLocationName != nil || a.Slate.VodSourceName != nil) {
+		temp["slate"] = []interface{}{map[string]interface{}{
+			"source_location_name": a.Slate.SourceLocationName,
+			"vod_source_name":      a.Slate.VodSourceName,
+		}}
+	}
+
+	if a.SpliceInsertMessage != nil {
+		temp["splice_insert_message"] = []interface{}{map[string]interface{}{
+			"avail_num":         a.SpliceInsertMessage.AvailNum,
+			"avails_expected":   a.SpliceInsertMessage.AvailsExpected,
+			"splice_event_id":   a.SpliceInsertMessage.SpliceEventId,
+			"unique_program_id": a.SpliceInsertMessage.UniqueProgramId,
+		}}
+	}
+
+	if a.TimeSignalMessage != nil {
+		var descriptors []interface{}
+		for _, s := range a.TimeSignalMessage.SegmentationDescriptors {
+			descriptors = append(descriptors, flattenSegmentationDescriptor(s))
+		}
+		temp["time_signal_message"] = []interface{}{map[string]interface{}{
+			"segmentation_descriptors": descriptors,
+		}}
+	}
+	return temp
+}
+
+func setAdBreaks(values []*mediatailor.AdBreak, d *schema.ResourceData) error {
+	var adBreaks []map[string]interface{}
+	for _, a := range values {
+		adBreaks = append(adBreaks, flattenAdBreak(a))
+	}
+	if err := setAttribute(d, "ad_breaks", adBreaks); err != nil {
+		return fmt.Errorf("error while setting the ad breaks: %w", err)
+	}
+	return nil
+}
+
+func setProgram(values *mediatailor.DescribeProgramOutput, d *schema.ResourceData) diag.Diagnostics {
+	var errors []error
+
+	errors = append(errors, setAdBreaks(values.AdBreaks, d))
+	if values.Arn != nil {
+		errors = append(errors, setAttribute(d, "arn", values.Arn))
+	}
+	if values.ChannelName != nil {
+		errors = append(errors, setAttribute(d, "channel_name", values.ChannelName))
+	}
+	if values.CreationTime != nil {
+		errors = append(errors, setAttribute(d, "creation_time", values.CreationTime.String()))
+	}
+	errors = append(errors, setAttribute(d, "duration_millis", values.DurationMillis))
+	errors = append(errors, setAttribute(d, "live_source_name", values.LiveSourceName))
+	if values.ProgramName != nil {
+		errors = append(errors, setAttribute(d, "name", values.ProgramName))
+	}
+	if values.ScheduledStartTime != nil {
+		errors = append(errors, setAttribute(d, "scheduled_start_time", values.ScheduledStartTime.String()))
+	}
+	if values.SourceLocationName != nil {
+		errors = append(errors, setAttribute(d, "source_location_name", values.SourceLocationName))
+	}
+	errors = append(errors, setAttribute(d, "vod_source_name", values.VodSourceName))
+
+	return diagnosticsFromErrors(errors)
+}
+
+func validateAdBreaks(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for i, v := range d.Get("ad_breaks").([]interface{}) {
+		if v == nil {
+			continue
+		}
+		adBreak := v.(map[string]interface{})
+		messageType := adBreak["message_type"].(string)
+		if len(adBreak["splice_insert_message"].([]interface{})) > 0 && messageType == "TIME_SIGNAL" {
+			return fmt.Errorf("ad_breaks.%d: splice_insert_message cannot be used with the TIME_SIGNAL message type", i)
+		}
+		if len(adBreak["time_signal_message"].([]interface{})) > 0 && messageType != "TIME_SIGNAL" {
+			return fmt.Errorf("ad_breaks.%d: time_signal_message requires the TIME_SIGNAL message type", i)
+		}
+	}
+	return nil
+}
+
+// transitionChanged reports whether a field of the transition changed from a value in the state. The transition is not
+// returned by DescribeProgram, so it is empty after an import and declaring it again must not replace the program.
+func transitionChanged(_ context.Context, old, new, _ interface{}) bool {
+	return old.(string) != "" && old.(string) != new.(string)
+}
+
+func validateTransition(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("schedule_configuration.0.relative_program") || !d.NewValueKnown("schedule_configuration.0.scheduled_start_time_millis") {
+		return nil
+	}
+	switch d.Get("schedule_configuration.0.transition_type").(string) {
+	case "RELATIVE":
+		if d.Get("schedule_configuration.0.relative_program").(string) == "" {
+			return fmt.Errorf("schedule_configuration.0: relative_program is required with the RELATIVE transition type")
+		}
+	case "ABSOLUTE":
+		if d.Get("schedule_configuration.0.scheduled_start_time_millis").(int) == 0 {
+			return fmt.Errorf("schedule_configuration.0: scheduled_start_time_millis is required with the ABSOLUTE transition type")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package awsmt
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"reflect"
+	"testing"
+)
+
+func TestAdBreaksRoundTrip(t *testing.T) {
+	// arrange
+	input := []*mediatailor.AdBreak{
+		{
+			MessageType:  aws.String("SPLICE_INSERT"),
+			OffsetMillis: aws.Int64(10000),
+			Slate:        &mediatailor.SlateSource{SourceLocationName: aws.String("source_location"), VodSourceName: aws.String("slate")},
+			SpliceInsertMessage: &mediatailor.SpliceInsertMessage{
+				AvailNum:        aws.Int64(1),
+				AvailsExpected:  aws.Int64(2),
+				SpliceEventId:   aws.Int64(3),
+				UniqueProgramId: aws.Int64(4),
+			},
+		},
+		{
+			MessageType:  aws.String("TIME_SIGNAL"),
+			OffsetMillis: aws.Int64(20000),
+			TimeSignalMessage: &mediatailor.TimeSignalMessage{
+				SegmentationDescriptors: []*mediatailor.SegmentationDescriptor{{
+					SegmentNum:           aws.Int64(1),
+					SegmentationEventId:  aws.Int64(2),
+					SegmentationTypeId:   aws.Int64(52),
+					SegmentationUpid:     aws.String("0123456789abcdef"),
+					SegmentationUpidType: aws.Int64(14),
+					SegmentsExpected:     aws.Int64(3),
+					SubSegmentNum:        aws.Int64(4),
+					SubSegmentsExpected:  aws.Int64(5),
+				}},
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceProgram().Schema, map[string]interface{}{})
+	// act
+	if err := setAdBreaks(input, d); err != nil {
+		t.Fatalf("Error setting the ad breaks: %v", err)
+	}
+	output := getAdBreaks(d)
+	// assert
+	if !reflect.DeepEqual(input, output) {
+		t.Fatalf("Not matching. Expected:\n%v\nGot\n%v", input, output)
+	}
+}
+
+func TestAdBreaksEmpty(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceProgram().Schema, map[string]interface{}{})
+	if err := setAdBreaks(nil, d); err != nil {
+		t.Fatalf("Error setting the ad breaks: %v", err)
+	}
+	if output := getAdBreaks(d); output != nil {
+		t.Fatalf("Expected no ad breaks, got %v", output)
+	}
+}
+
+func TestAdBreaksExplicitZero(t *testing.T) {
+	// arrange: avail_num and segment_num are set to 0 in the configuration, the other attributes are not set
+	rawConfig := cty.ObjectVal(map[string]cty.Value{
+		"ad_breaks": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"splice_insert_message": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"avail_num":       cty.NumberIntVal(0),
+					"avails_expected": cty.NullVal(cty.Number),
+				})}),
+				"time_signal_message": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"segmentation_descriptors": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+						"segment_num":          cty.NumberIntVal(0),
+						"segmentation_type_id": cty.NullVal(cty.Number),
+					})}),
+				})}),
+			}),
+		}),
+	})
+	d := resourceProgram().Data(&terraform.InstanceState{ID: "channel/program", RawConfig: rawConfig})
+	adBreaks := []interface{}{map[string]interface{}{
+		"message_type":          "SPLICE_INSERT",
+		"offset_millis":         0,
+		"splice_insert_message": []interface{}{map[string]interface{}{"avail_num": 0, "avails_expected": 0}},
+		"time_signal_message": []interface{}{map[string]interface{}{
+			"segmentation_descriptors": []interface{}{map[string]interface{}{"segment_num": 0, "segmentation_type_id": 0}},
+		}},
+	}}
+	if err := d.Set("ad_breaks", adBreaks); err != nil {
+		t.Fatal(err)
+	}
+
+	// act
+	output := getAdBreaks(d)
+
+	// assert
+	if len(output) != 1 || output[0].SpliceInsertMessage == nil || output[0].TimeSignalMessage == nil {
+		t.Fatalf("expected one ad break with messages, got %v", output)
+	}
+	if v := output[0].SpliceInsertMessage; aws.Int64Value(v.AvailNum) != 0 || v.AvailNum == nil || v.AvailsExpected != nil {
+		t.Errorf("expected avail_num to be sent as 0 and avails_expected not to be sent, got %v", v)
+	}
+	if v := output[0].TimeSignalMessage.SegmentationDescriptors[0]; v.SegmentNum == nil || aws.Int64Value(v.SegmentNum) != 0 || v.SegmentationTypeId != nil {
+		t.Errorf("expected segment_num to be sent as 0 and segmentation_type_id not to be sent, got %v", v)
+	}
+}
+
+func TestGetUpdateProgramInput_removedAdBreaks(t *testing.T) {
+	// arrange
+	d := schema.TestResourceDataRaw(t, resourceProgram().Schema, map[string]interface{}{"channel_name": "test", "name": "test"})
+
+	// act
+	input := getUpdateProgramInput(d)
+
+	// assert
+	if input.AdBreaks == nil || len(input.AdBreaks) != 0 {
+		t.Fatalf("Expected an empty list of ad breaks, got %v", input.AdBreaks)
+	}
+}
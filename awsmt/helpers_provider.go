@@ -0,0 +1,301 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/defaults"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const defaultRegion = "eu-central-1"
+
+// getRegion returns the region of the provider configuration, then the one of the AWS_REGION and AWS_DEFAULT_REGION
+// environment variables, then the default region.
+func getRegion(d *schema.ResourceData) string {
+	if v, ok := d.GetOk("region"); ok {
+		return v.(string)
+	}
+	for _, env := range []string{"AWS_REGION", "AWS_DEFAULT_REGION"} {
+		if v := os.Getenv(env); v != "" {
+			return v
+		}
+	}
+	return defaultRegion
+}
+
+func expandPath(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error while expanding the path %s: %v", path, err)
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// getSharedConfigFiles returns the shared config files followed by the shared credentials files, so that the values of
+// the credentials files take precedence, as they do in the AWS CLI. The SDK replaces all its default files by the files
+// of the session options, so the default files are returned for the list that is not configured, if the other one is.
+func getSharedConfigFiles(d *schema.ResourceData) ([]string, error) {
+	lists := []struct {
+		key         string
+		envVar      string
+		defaultPath func() string
+	}{
+		{"shared_config_files", "AWS_CONFIG_FILE", defaults.SharedConfigFilename},
+		{"shared_credentials_files", "AWS_SHARED_CREDENTIALS_FILE", defaults.SharedCredentialsFilename},
+	}
+	if len(d.Get(lists[0].key).([]interface{})) == 0 && len(d.Get(lists[1].key).([]interface{})) == 0 {
+		return nil, nil
+	}
+	var files []string
+	for _, list := range lists {
+		paths := d.Get(list.key).([]interface{})
+		if len(paths) == 0 {
+			if v := os.Getenv(list.envVar); v != "" {
+				files = append(files, v)
+			} else {
+				files = append(files, list.defaultPath())
+			}
+			continue
+		}
+		for _, f := range paths {
+			path, err := expandPath(f.(string))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
+
+// getSessionOptions returns the options of the session for the credential source with the highest precedence, and the
+// description of every configured credential source, ordered by precedence. The precedence order is:
+//  1. the access_key and secret_key of the provider configuration;
+//  2. the profile of the provider configuration;
+//  3. the AWS_PROFILE environment variable;
+//  4. the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables;
+//  5. the default credential chain of the SDK (default profile of the shared files, container or instance role).
+func getSessionOptions(d *schema.ResourceData, region string) (session.Options, []string, error) {
+	options := session.Options{Config: aws.Config{Region: aws.String(region)}}
+	var sources []string
+
+	files, err := getSharedConfigFiles(d)
+	if err != nil {
+		return options, nil, err
+	}
+	if len(files) > 0 {
+		options.SharedConfigFiles = files
+		options.SharedConfigState = session.SharedConfigEnable
+	}
+
+	if v, ok := d.GetOk("access_key"); ok {
+		sources = append(sources, "access_key and secret_key of the provider configuration")
+		options.Config.Credentials = credentials.NewStaticCredentials(v.(string), d.Get("secret_key").(string), d.Get("token").(string))
+	}
+	if v := d.Get("profile").(string); v != "" {
+		sources = append(sources, fmt.Sprintf("profile '%s' of the provider configuration", v))
+		if len(sources) == 1 {
+			options.Profile = v
+			options.SharedConfigState = session.SharedConfigEnable
+		}
+	}
+	if v := os.Getenv("AWS_PROFILE"); v != "" {
+		sources = append(sources, fmt.Sprintf("profile '%s' of the AWS_PROFILE environment variable", v))
+		if len(sources) == 1 {
+			options.Profile = v
+			options.SharedConfigState = session.SharedConfigEnable
+		}
+	}
+	if os.Getenv("AWS_ACCESS_KEY_ID") != "" && os.Getenv("AWS_SECRET_ACCESS_KEY") != "" {
+		sources = append(sources, "AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables")
+	}
+	if len(sources) == 0 {
+		sources = append(sources, "default credential chain")
+	}
+	return options, sources, nil
+}
+
+func validateAssumeRoleDuration(v interface{}, k string) (ws []string, errors []error) {
+	duration, err := time.ParseDuration(v.(string))
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q cannot be parsed as a duration: %v", k, err))
+		return
+	}
+	if duration < 15*time.Minute || duration > 12*time.Hour {
+		errors = append(errors, fmt.Errorf("%q must be between 15 minutes (15m) and 12 hours (12h), got %s", k, duration))
+	}
+	return
+}
+
+func getPolicyArns(values map[string]interface{}) []*sts.PolicyDescriptorType {
+	var policyArns []*sts.PolicyDescriptorType
+	if v, ok := values["policy_arns"]; ok {
+		for _, p := range v.(*schema.Set).List() {
+			policyArns = append(policyArns, &sts.PolicyDescriptorType{Arn: aws.String(p.(string))})
+		}
+	}
+	return policyArns
+}
+
+func getAssumeRoleOptions(values map[string]interface{}) func(*stscreds.AssumeRoleProvider) {
+	return func(p *stscreds.AssumeRoleProvider) {
+		if v, ok := values["duration"]; ok && v.(string) != "" {
+			// the duration has already been validated by validateAssumeRoleDuration
+			p.Duration, _ = time.ParseDuration(v.(string))
+		}
+		if v, ok := values["external_id"]; ok && v.(string) != "" {
+			p.ExternalID = aws.String(v.(string))
+		}
+		if v, ok := values["policy"]; ok && v.(string) != "" {
+			p.Policy = aws.String(v.(string))
+		}
+		p.PolicyArns = getPolicyArns(values)
+		if v, ok := values["session_name"]; ok && v.(string) != "" {
+			p.RoleSessionName = v.(string)
+		}
+		if v, ok := values["tags"]; ok {
+			for key, value := range v.(map[string]interface{}) {
+				p.Tags = append(p.Tags, &sts.Tag{Key: aws.String(key), Value: aws.String(value.(string))})
+			}
+		}
+		if v, ok := values["transitive_tag_keys"]; ok {
+			for _, key := range v.(*schema.Set).List() {
+				p.TransitiveTagKeys = append(p.TransitiveTagKeys, aws.String(key.(string)))
+			}
+		}
+	}
+}
+
+// webIdentityToken is a stscreds.TokenFetcher returning the token declared in the provider configuration.
+type webIdentityToken string
+
+func (t webIdentityToken) FetchToken(_ credentials.Context) ([]byte, error) {
+	return []byte(t), nil
+}
+
+func getWebIdentityRoleProvider(client *sts.STS, values map[string]interface{}) (*stscreds.WebIdentityRoleProvider, error) {
+	var tokenFetcher stscreds.TokenFetcher
+	if v, ok := values["web_identity_token"]; ok && v.(string) != "" {
+		tokenFetcher = webIdentityToken(v.(string))
+	} else if v, ok := values["web_identity_token_file"]; ok && v.(string) != "" {
+		tokenFetcher = stscreds.FetchTokenPath(v.(string))
+	} else {
+		return nil, fmt.Errorf("either web_identity_token or web_identity_token_file must be specified in assume_role_with_web_identity")
+	}
+
+	sessionName := values["session_name"].(string)
+	return stscreds.NewWebIdentityRoleProviderWithOptions(client, values["role_arn"].(string), sessionName, tokenFetcher, func(p *stscreds.WebIdentityRoleProvider) {
+		if v, ok := values["duration"]; ok && v.(string) != "" {
+			p.Duration, _ = time.ParseDuration(v.(string))
+		}
+		p.PolicyArns = getPolicyArns(values)
+	}), nil
+}
+
+// configureAssumeRoleCredentials replaces the credentials of the session with the ones of the roles declared in the
+// provider configuration. The web identity role is assumed first, so that the role declared in assume_role can be
+// assumed with its credentials.
+func configureAssumeRoleCredentials(sess *session.Session, d *schema.ResourceData) error {
+	configured := false
+
+	if v, ok := d.GetOk("assume_role_with_web_identity"); ok && v.([]interface{})[0] != nil {
+		p, err := getWebIdentityRoleProvider(sts.New(sess, getEndpointConfig(d, "sts")), v.([]interface{})[0].(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+		sess.Config.Credentials = credentials.NewCredentials(p)
+		configured = true
+	}
+
+	if v, ok := d.GetOk("assume_role"); ok && v.([]interface{})[0] != nil {
+		val := v.([]interface{})[0].(map[string]interface{})
+		// the STS client is created after the web identity credentials are set, so that the roles can be chained
+		client := sts.New(sess, getEndpointConfig(d, "sts"))
+		sess.Config.Credentials = stscreds.NewCredentialsWithClient(client, val["role_arn"].(string), getAssumeRoleOptions(val))
+		configured = true
+	}
+
+	// the credentials are retrieved lazily, they are retrieved here to report errors before any MediaTailor call
+	if configured && !d.Get("skip_credentials_validation").(bool) {
+		if _, err := sess.Config.Credentials.Get(); err != nil {
+			return fmt.Errorf("error while assuming the role: %v", err)
+		}
+	}
+	return nil
+}
+
+// getEndpointConfig returns the configuration of the client of the given service, with the custom endpoint declared in
+// the endpoints block of the provider configuration, if any.
+func getEndpointConfig(d *schema.ResourceData, service string) *aws.Config {
+	config := &aws.Config{}
+	if v, ok := d.GetOk("endpoints"); ok && v.([]interface{})[0] != nil {
+		val := v.([]interface{})[0].(map[string]interface{})
+		if endpoint, ok := val[service]; ok && endpoint.(string) != "" {
+			config.Endpoint = aws.String(endpoint.(string))
+		}
+	}
+	return config
+}
+
+// getAccountId validates the credentials of the session and returns the ID of the account they belong to, with a
+// single call to the STS API. The call is skipped when both skip_credentials_validation and skip_requesting_account_id
+// are set, which is required by the emulators that do not implement the STS API. If only the validation is skipped, a
+// failure to request the account ID is logged instead of being returned.
+func getAccountId(ctx context.Context, sess *session.Session, d *schema.ResourceData) (string, error) {
+	skipValidation := d.Get("skip_credentials_validation").(bool)
+	skipAccountId := d.Get("skip_requesting_account_id").(bool)
+	if skipValidation && skipAccountId {
+		return "", nil
+	}
+
+	res, err := sts.New(sess, getEndpointConfig(d, "sts")).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil && skipValidation {
+		tflog.Warn(ctx, "Unable to request the account ID", map[string]interface{}{"error": err.Error()})
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error while getting the caller identity: %v", err)
+	}
+	if skipAccountId {
+		return "", nil
+	}
+	return aws.StringValue(res.Account), nil
+}
+
+// @ADR
+// Context: Developers have run applies of test modules with credentials of the production account.
+// Decision: We decided to compare the account of the credentials with allowed_account_ids and forbidden_account_ids
+// when the provider is configured, before the MediaTailor client is created.
+// Consequences: The guardrails need the account ID, so they cannot be used together with skip_requesting_account_id.
+
+// checkAccountId returns an error if the account is not in allowed_account_ids, or if it is in forbidden_account_ids.
+func checkAccountId(d *schema.ResourceData, accountId string) error {
+	allowed := d.Get("allowed_account_ids").(*schema.Set)
+	forbidden := d.Get("forbidden_account_ids").(*schema.Set)
+	if allowed.Len() == 0 && forbidden.Len() == 0 {
+		return nil
+	}
+	if accountId == "" {
+		return fmt.Errorf("the account ID could not be requested, which is required by allowed_account_ids and forbidden_account_ids. Remove skip_requesting_account_id from the provider configuration")
+	}
+	if allowed.Len() > 0 && !allowed.Contains(accountId) {
+		return fmt.Errorf("the account %s is not in the allowed_account_ids of the provider configuration", accountId)
+	}
+	if forbidden.Contains(accountId) {
+		return fmt.Errorf("the account %s is in the forbidden_account_ids of the provider configuration", accountId)
+	}
+	return nil
+}
@@ -0,0 +1,331 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/defaults"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateAssumeRoleDuration(t *testing.T) {
+	cases := map[string]bool{"15m": true, "1h": true, "12h": true, "14m": false, "13h": false, "one hour": false}
+	for duration, valid := range cases {
+		_, errors := validateAssumeRoleDuration(duration, "duration")
+		if (len(errors) == 0) != valid {
+			t.Errorf("%s: expected valid to be %v, got errors %v", duration, valid, errors)
+		}
+	}
+}
+
+func TestGetAssumeRoleOptions(t *testing.T) {
+	// arrange
+	values := map[string]interface{}{
+		"duration":            "1h",
+		"external_id":         "external",
+		"policy":              `{"Version":"2012-10-17"}`,
+		"policy_arns":         schema.NewSet(schema.HashString, []interface{}{"arn:aws:iam::aws:policy/ReadOnlyAccess"}),
+		"role_arn":            "arn:aws:iam::123456789012:role/test",
+		"session_name":        "session",
+		"tags":                map[string]interface{}{"Team": "video"},
+		"transitive_tag_keys": schema.NewSet(schema.HashString, []interface{}{"Team"}),
+	}
+	expected := stscreds.AssumeRoleProvider{
+		Duration:          time.Hour,
+		ExternalID:        aws.String("external"),
+		Policy:            aws.String(`{"Version":"2012-10-17"}`),
+		PolicyArns:        []*sts.PolicyDescriptorType{{Arn: aws.String("arn:aws:iam::aws:policy/ReadOnlyAccess")}},
+		RoleSessionName:   "session",
+		Tags:              []*sts.Tag{{Key: aws.String("Team"), Value: aws.String("video")}},
+		TransitiveTagKeys: []*string{aws.String("Team")},
+	}
+
+	// act
+	var output stscreds.AssumeRoleProvider
+	getAssumeRoleOptions(values)(&output)
+
+	// assert
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("expected %v, got %v", expected, output)
+	}
+}
+
+func TestGetWebIdentityRoleProvider(t *testing.T) {
+	client := sts.New(session.Must(session.NewSession(&aws.Config{Region: aws.String("eu-central-1")})))
+	values := map[string]interface{}{"role_arn": "arn:aws:iam::123456789012:role/test", "session_name": "", "web_identity_token": "", "web_identity_token_file": ""}
+
+	if _, err := getWebIdentityRoleProvider(client, values); err == nil {
+		t.Errorf("expected an error when no token is specified")
+	}
+
+	values["web_identity_token"] = "token"
+	p, err := getWebIdentityRoleProvider(client, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p == nil {
+		t.Errorf("expected a web identity role provider")
+	}
+}
+
+func TestGetRegion(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{})
+	if region := getRegion(d); region != defaultRegion {
+		t.Errorf("expected %s, got %s", defaultRegion, region)
+	}
+
+	t.Setenv("AWS_DEFAULT_REGION", "us-east-1")
+	if region := getRegion(d); region != "us-east-1" {
+		t.Errorf("expected us-east-1, got %s", region)
+	}
+
+	t.Setenv("AWS_REGION", "us-west-2")
+	if region := getRegion(d); region != "us-west-2" {
+		t.Errorf("expected us-west-2, got %s", region)
+	}
+
+	d = schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{"region": "eu-west-1"})
+	if region := getRegion(d); region != "eu-west-1" {
+		t.Errorf("expected eu-west-1, got %s", region)
+	}
+}
+
+func TestGetSessionOptions(t *testing.T) {
+	// arrange
+	t.Setenv("AWS_PROFILE", "env_profile")
+	t.Setenv("AWS_ACCESS_KEY_ID", "env_key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "env_secret")
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"access_key":               "key",
+		"profile":                  "profile",
+		"secret_key":               "secret",
+		"shared_config_files":      []interface{}{"/tmp/config"},
+		"shared_credentials_files": []interface{}{"/tmp/credentials"},
+	})
+
+	// act
+	options, sources, err := getSessionOptions(d, "eu-central-1")
+
+	// assert
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sources) != 4 {
+		t.Errorf("expected 4 credential sources, got %v", sources)
+	}
+	if options.Profile != "" {
+		t.Errorf("expected the static credentials to take precedence over the profile, got profile %s", options.Profile)
+	}
+	if v, err := options.Config.Credentials.Get(); err != nil || v.AccessKeyID != "key" || v.SecretAccessKey != "secret" {
+		t.Errorf("expected the static credentials of the provider configuration, got %v (%v)", v, err)
+	}
+	if !reflect.DeepEqual(options.SharedConfigFiles, []string{"/tmp/config", "/tmp/credentials"}) {
+		t.Errorf("unexpected shared config files %v", options.SharedConfigFiles)
+	}
+}
+
+func TestGetSharedConfigFiles(t *testing.T) {
+	t.Setenv("AWS_CONFIG_FILE", "")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/tmp/env_credentials")
+	cases := map[string]struct {
+		config   map[string]interface{}
+		expected []string
+	}{
+		"no files":          {config: map[string]interface{}{}, expected: nil},
+		"both files":        {config: map[string]interface{}{"shared_config_files": []interface{}{"/tmp/config"}, "shared_credentials_files": []interface{}{"/tmp/credentials"}}, expected: []string{"/tmp/config", "/tmp/credentials"}},
+		"config files":      {config: map[string]interface{}{"shared_config_files": []interface{}{"/tmp/config"}}, expected: []string{"/tmp/config", "/tmp/env_credentials"}},
+		"credentials files": {config: map[string]interface{}{"shared_credentials_files": []interface{}{"/tmp/credentials"}}, expected: []string{defaults.SharedConfigFilename(), "/tmp/credentials"}},
+	}
+	for name, c := range cases {
+		d := schema.TestResourceDataRaw(t, Provider().Schema, c.config)
+		files, err := getSharedConfigFiles(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(files, c.expected) {
+			t.Errorf("%s: expected %v, got %v", name, c.expected, files)
+		}
+	}
+}
+
+func TestGetSessionOptions_profile(t *testing.T) {
+	t.Setenv("AWS_PROFILE", "env_profile")
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{"profile": "profile"})
+	options, sources, err := getSessionOptions(d, "eu-central-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if options.Profile != "profile" || len(sources) != 2 {
+		t.Errorf("expected the profile of the provider configuration to take precedence, got %s (%v)", options.Profile, sources)
+	}
+
+	d = schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{})
+	options, sources, err = getSessionOptions(d, "eu-central-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if options.Profile != "env_profile" || len(sources) != 1 {
+		t.Errorf("expected the profile of the environment, got %s (%v)", options.Profile, sources)
+	}
+}
+
+func TestGetEndpointConfig(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"endpoints": []interface{}{map[string]interface{}{"mediatailor": "http://localhost:4566"}},
+	})
+
+	if v := aws.StringValue(getEndpointConfig(d, "mediatailor").Endpoint); v != "http://localhost:4566" {
+		t.Errorf("expected the custom MediaTailor endpoint, got %s", v)
+	}
+	if v := getEndpointConfig(d, "sts").Endpoint; v != nil {
+		t.Errorf("expected no custom STS endpoint, got %s", *v)
+	}
+}
+
+func newTestStsServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = fmt.Fprint(w, `<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <GetCallerIdentityResult>
+    <Arn>arn:aws:iam::123456789012:user/test</Arn>
+    <UserId>AIDACKCEVSQ6C2EXAMPLE</UserId>
+    <Account>123456789012</Account>
+  </GetCallerIdentityResult>
+</GetCallerIdentityResponse>`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestProviderConfigure_endpoints(t *testing.T) {
+	// arrange
+	server := newTestStsServer(t)
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	p := Provider()
+	config := map[string]interface{}{
+		"access_key": "key",
+		"secret_key": "secret",
+		"endpoints":  []interface{}{map[string]interface{}{"mediatailor": server.URL, "sts": server.URL}},
+	}
+
+	// act
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+
+	// assert
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(diags) != 0 {
+		t.Errorf("expected no warning with a single credential source, got %v", diags)
+	}
+	meta := p.Meta().(*providerMeta)
+	if endpoint := meta.client.(*mediatailor.MediaTailor).Endpoint; endpoint != server.URL {
+		t.Errorf("expected the MediaTailor endpoint %s, got %s", server.URL, endpoint)
+	}
+	if meta.accountId != "123456789012" {
+		t.Errorf("expected the account ID 123456789012, got %s", meta.accountId)
+	}
+}
+
+func TestProviderConfigure_ignoredCredentialSources(t *testing.T) {
+	// arrange
+	server := newTestStsServer(t)
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_ACCESS_KEY_ID", "env_key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "env_secret")
+	p := Provider()
+	config := map[string]interface{}{
+		"access_key": "key",
+		"secret_key": "secret",
+		"endpoints":  []interface{}{map[string]interface{}{"mediatailor": server.URL, "sts": server.URL}},
+	}
+
+	// act
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+
+	// assert
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail, "AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables") {
+		t.Errorf("expected a warning naming the ignored credential source, got %v", diags)
+	}
+}
+
+func TestProviderConfigure_skipCredentialsValidation(t *testing.T) {
+	t.Setenv("AWS_PROFILE", "")
+	p := Provider()
+	config := map[string]interface{}{
+		"access_key":                  "key",
+		"secret_key":                  "secret",
+		"endpoints":                   []interface{}{map[string]interface{}{"sts": "http://127.0.0.1:1"}},
+		"skip_credentials_validation": true,
+		"skip_requesting_account_id":  true,
+	}
+
+	if diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config)); diags.HasError() {
+		t.Fatalf("expected the STS API not to be called, got: %v", diags)
+	}
+}
+
+func TestCheckAccountId(t *testing.T) {
+	cases := map[string]struct {
+		config      map[string]interface{}
+		accountId   string
+		expectError bool
+	}{
+		"no guardrails":         {config: map[string]interface{}{}, accountId: "", expectError: false},
+		"allowed account":       {config: map[string]interface{}{"allowed_account_ids": []interface{}{"123456789012"}}, accountId: "123456789012", expectError: false},
+		"not allowed account":   {config: map[string]interface{}{"allowed_account_ids": []interface{}{"123456789012"}}, accountId: "210987654321", expectError: true},
+		"forbidden account":     {config: map[string]interface{}{"forbidden_account_ids": []interface{}{"123456789012"}}, accountId: "123456789012", expectError: true},
+		"not forbidden account": {config: map[string]interface{}{"forbidden_account_ids": []interface{}{"123456789012"}}, accountId: "210987654321", expectError: false},
+		"unknown account":       {config: map[string]interface{}{"forbidden_account_ids": []interface{}{"123456789012"}}, accountId: "", expectError: true},
+	}
+	for name, c := range cases {
+		d := schema.TestResourceDataRaw(t, Provider().Schema, c.config)
+		if err := checkAccountId(d, c.accountId); (err != nil) != c.expectError {
+			t.Errorf("%s: expected error to be %v, got %v", name, c.expectError, err)
+		}
+	}
+}
+
+func TestProviderConfigure_forbiddenAccountIds(t *testing.T) {
+	// arrange
+	server := newTestStsServer(t)
+	t.Setenv("AWS_PROFILE", "")
+	p := Provider()
+	config := map[string]interface{}{
+		"access_key":            "key",
+		"secret_key":            "secret",
+		"endpoints":             []interface{}{map[string]interface{}{"mediatailor": server.URL, "sts": server.URL}},
+		"forbidden_account_ids": []interface{}{"123456789012"},
+	}
+
+	// act
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+
+	// assert
+	if !diags.HasError() {
+		t.Fatalf("expected the forbidden account to be refused")
+	}
+	if p.Meta() != nil {
+		t.Errorf("expected the provider not to be configured")
+	}
+}
@@ -0,0 +1,134 @@
+package awsmt
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/aws/aws-sdk-go/service/mediatailor/mediatailoriface"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"sync"
+	"time"
+)
+
+const (
+	retryModeStandard = "standard"
+	retryModeAdaptive = "adaptive"
+	minRetryDelay     = 100 * time.Millisecond
+	minThrottleDelay  = 500 * time.Millisecond
+	maxRetryDelay     = 30 * time.Second
+)
+
+// @ADR
+// Context: Applying modules with many sources sends bursts of requests to MediaTailor, which answers with
+// TooManyRequestsException, and the apply fails mid-way.
+// Decision: We decided to retry the throttling errors and the transient 5xx errors in the SDK client, with a jittered
+// exponential backoff, instead of retrying in every CRUD function. In the adaptive retry mode, the client also delays
+// every request after a throttling error, so that the whole apply slows down instead of single requests.
+// Consequences: Every MediaTailor call of the provider is retried, and a long throttling period can make an apply
+// last up to max_retries times the maximum delay of 30 seconds.
+
+// retryer is the request.Retryer of the MediaTailor client. It relies on client.DefaultRetryer to choose which errors
+// are retried and to compute the jittered delays, and logs every retry.
+type retryer struct {
+	client.DefaultRetryer
+}
+
+func newRetryer(maxRetries int) retryer {
+	return retryer{client.DefaultRetryer{
+		NumMaxRetries:    maxRetries,
+		MinRetryDelay:    minRetryDelay,
+		MinThrottleDelay: minThrottleDelay,
+		MaxRetryDelay:    maxRetryDelay,
+		MaxThrottleDelay: maxRetryDelay,
+	}}
+}
+
+func (r retryer) RetryRules(req *request.Request) time.Duration {
+	delay := r.DefaultRetryer.RetryRules(req)
+	tflog.Warn(req.Context(), "Retrying the MediaTailor request", map[string]interface{}{
+		"operation": req.Operation.Name,
+		"attempt":   req.RetryCount + 1,
+		"delay":     delay.String(),
+		"error":     req.Error.Error(),
+	})
+	return delay
+}
+
+// adaptiveRateLimiter delays the requests of a client after a throttling error. The delay doubles with every throttling
+// error and halves with every successful request.
+type adaptiveRateLimiter struct {
+	mu    sync.Mutex
+	delay time.Duration
+}
+
+func (l *adaptiveRateLimiter) update(throttled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	switch {
+	case throttled && l.delay == 0:
+		l.delay = minThrottleDelay
+	case throttled:
+		l.delay *= 2
+		if l.delay > maxRetryDelay {
+			l.delay = maxRetryDelay
+		}
+	case l.delay > 0:
+		l.delay /= 2
+		if l.delay < minThrottleDelay {
+			l.delay = 0
+		}
+	}
+}
+
+func (l *adaptiveRateLimiter) currentDelay() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.delay
+}
+
+func (l *adaptiveRateLimiter) wait(req *request.Request) {
+	delay := l.currentDelay()
+	if delay == 0 {
+		return
+	}
+	tflog.Debug(req.Context(), "Delaying the MediaTailor request after throttling", map[string]interface{}{
+		"operation": req.Operation.Name,
+		"delay":     delay.String(),
+	})
+	if err := aws.SleepWithContext(req.Context(), delay); err != nil {
+		req.Error = err
+	}
+}
+
+func (l *adaptiveRateLimiter) complete(req *request.Request) {
+	if req.Error != nil {
+		if req.IsErrorThrottle() {
+			l.update(true)
+		}
+		return
+	}
+	l.update(false)
+}
+
+// getMediaTailorClientFactory returns a function creating the MediaTailor client of a region, configured with the
+// custom endpoint and the retry settings of the provider configuration.
+func getMediaTailorClientFactory(sess *session.Session, d *schema.ResourceData) func(region string) mediatailoriface.MediaTailorAPI {
+	endpoint := getEndpointConfig(d, "mediatailor").Endpoint
+	maxRetries := d.Get("max_retries").(int)
+	adaptive := d.Get("retry_mode").(string) == retryModeAdaptive
+
+	return func(region string) mediatailoriface.MediaTailorAPI {
+		config := request.WithRetryer(&aws.Config{Endpoint: endpoint, Region: aws.String(region)}, newRetryer(maxRetries))
+		c := mediatailor.New(sess, config)
+
+		if adaptive {
+			limiter := &adaptiveRateLimiter{}
+			c.Handlers.Send.PushFrontNamed(request.NamedHandler{Name: "awsmt.AdaptiveRateLimiterWait", Fn: limiter.wait})
+			c.Handlers.CompleteAttempt.PushBackNamed(request.NamedHandler{Name: "awsmt.AdaptiveRateLimiterComplete", Fn: limiter.complete})
+		}
+		return c
+	}
+}
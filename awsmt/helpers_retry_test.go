@@ -0,0 +1,103 @@
+package awsmt
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/aws/aws-sdk-go/service/mediatailor/mediatailoriface"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newTestRetryClient(t *testing.T, statusCodes []int, retryMode string) (mediatailoriface.MediaTailorAPI, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(atomic.AddInt32(&calls, 1)) - 1
+		if i < len(statusCodes) {
+			w.Header().Set("X-Amzn-Errortype", map[int]string{400: "BadRequestException", 429: "TooManyRequestsException", 500: "InternalServerException"}[statusCodes[i]])
+			w.WriteHeader(statusCodes[i])
+			_, _ = w.Write([]byte(`{"Message":"error"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"Items":[]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	sess := session.Must(session.NewSession(&aws.Config{Region: aws.String("eu-central-1"), Credentials: credentials.NewStaticCredentials("key", "secret", "")}))
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"endpoints":   []interface{}{map[string]interface{}{"mediatailor": server.URL}},
+		"max_retries": 2,
+		"retry_mode":  retryMode,
+	})
+	return getMediaTailorClientFactory(sess, d)("eu-central-1"), &calls
+}
+
+func TestRetryer(t *testing.T) {
+	cases := map[string]struct {
+		statusCodes   []int
+		expectedCalls int32
+		expectError   bool
+	}{
+		"throttling":        {statusCodes: []int{429, 429}, expectedCalls: 3},
+		"server error":      {statusCodes: []int{500}, expectedCalls: 2},
+		"too many errors":   {statusCodes: []int{429, 500, 429}, expectedCalls: 3, expectError: true},
+		"not retried error": {statusCodes: []int{400}, expectedCalls: 1, expectError: true},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			client, calls := newTestRetryClient(t, c.statusCodes, retryModeStandard)
+
+			// act
+			_, err := client.ListChannels(&mediatailor.ListChannelsInput{})
+
+			// assert
+			if (err != nil) != c.expectError {
+				t.Errorf("expected error to be %v, got %v", c.expectError, err)
+			}
+			if *calls != c.expectedCalls {
+				t.Errorf("expected %d calls, got %d", c.expectedCalls, *calls)
+			}
+		})
+	}
+}
+
+func TestRetryer_adaptive(t *testing.T) {
+	client, calls := newTestRetryClient(t, []int{429}, retryModeAdaptive)
+
+	if _, err := client.ListChannels(&mediatailor.ListChannelsInput{}); err != nil {
+		t.Fatal(err)
+	}
+	if *calls != 2 {
+		t.Errorf("expected 2 calls, got %d", *calls)
+	}
+}
+
+func TestAdaptiveRateLimiterUpdate(t *testing.T) {
+	l := adaptiveRateLimiter{}
+
+	l.update(true)
+	if l.currentDelay() != minThrottleDelay {
+		t.Errorf("expected %v after a throttling error, got %v", minThrottleDelay, l.currentDelay())
+	}
+	l.update(true)
+	if l.currentDelay() != 2*minThrottleDelay {
+		t.Errorf("expected %v after two throttling errors, got %v", 2*minThrottleDelay, l.currentDelay())
+	}
+	for i := 0; i < 10; i++ {
+		l.update(true)
+	}
+	if l.currentDelay() != maxRetryDelay {
+		t.Errorf("expected the delay to be capped to %v, got %v", maxRetryDelay, l.currentDelay())
+	}
+	for i := 0; i < 10; i++ {
+		l.update(false)
+	}
+	if l.currentDelay() != 0 {
+		t.Errorf("expected no delay after successful requests, got %v", l.currentDelay())
+	}
+}
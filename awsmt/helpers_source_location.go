@@ -1,10 +1,14 @@
 package awsmt
 
 import (
+	"context"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/aws/aws-sdk-go/service/mediatailor/mediatailoriface"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strings"
 )
 
 func setAccessConfiguration(values *mediatailor.DescribeSourceLocationOutput, d *schema.ResourceData) error {
@@ -24,7 +28,7 @@ func setAccessConfiguration(values *mediatailor.DescribeSourceLocationOutput, d
 				temp["smatc_secret_string_key"] = values.AccessConfiguration.SecretsManagerAccessTokenConfiguration.SecretStringKey
 			}
 		}
-		if err := d.Set("access_configuration", []interface{}{temp}); err != nil {
+		if err := setAttribute(d, "access_configuration", []interface{}{temp}); err != nil {
 			return fmt.Errorf("error while setting the access configuration: %w", err)
 		}
 	}
@@ -39,38 +43,31 @@ func setSegmentDeliveryConfigurations(values *mediatailor.DescribeSourceLocation
 		temp["name"] = c.Name
 		configurations = append(configurations, temp)
 	}
-	if err := d.Set("segment_delivery_configurations", configurations); err != nil {
+	if err := setAttribute(d, "segment_delivery_configurations", configurations); err != nil {
 		return fmt.Errorf("error while setting the segment delivery configurations: %w", err)
 	}
 	return nil
 }
 
-func setSourceLocation(values *mediatailor.DescribeSourceLocationOutput, d *schema.ResourceData) error {
+func setSourceLocation(values *mediatailor.DescribeSourceLocationOutput, d *schema.ResourceData) diag.Diagnostics {
 	var errors []error
 
 	errors = append(errors, setAccessConfiguration(values, d))
-	errors = append(errors, d.Set("arn", values.Arn))
-	errors = append(errors, d.Set("creation_time", values.CreationTime.String()))
+	errors = append(errors, setAttribute(d, "arn", values.Arn))
+	errors = append(errors, setAttribute(d, "creation_time", values.CreationTime.String()))
 	if values.DefaultSegmentDeliveryConfiguration != nil && values.DefaultSegmentDeliveryConfiguration != &(mediatailor.DefaultSegmentDeliveryConfiguration{}) {
-		errors = append(errors, d.Set("default_segment_delivery_configuration_url", values.DefaultSegmentDeliveryConfiguration.BaseUrl))
+		errors = append(errors, setAttribute(d, "default_segment_delivery_configuration_url", values.DefaultSegmentDeliveryConfiguration.BaseUrl))
 	}
 	if values.HttpConfiguration != nil && values.HttpConfiguration != &(mediatailor.HttpConfiguration{}) {
 		if values.HttpConfiguration.BaseUrl != nil {
-			errors = append(errors, d.Set("http_configuration_url", values.HttpConfiguration.BaseUrl))
+			errors = append(errors, setAttribute(d, "http_configuration_url", values.HttpConfiguration.BaseUrl))
 		}
 	}
-	errors = append(errors, d.Set("last_modified_time", values.LastModifiedTime.String()))
+	errors = append(errors, setAttribute(d, "last_modified_time", values.LastModifiedTime.String()))
 	errors = append(errors, setSegmentDeliveryConfigurations(values, d))
-	errors = append(errors, d.Set("name", values.SourceLocationName))
-	errors = append(errors, d.Set("tags", values.Tags))
+	errors = append(errors, setAttribute(d, "name", values.SourceLocationName))
 
-	for _, e := range errors {
-		if e != nil {
-			return fmt.Errorf("the following error occured while setting the values: %w", e)
-		}
-	}
-
-	return nil
+	return diagnosticsFromErrors(errors)
 }
 
 func getAccessConfiguration(d *schema.ResourceData) *mediatailor.AccessConfiguration {
@@ -190,28 +187,87 @@ func getUpdateSourceLocationInput(d *schema.ResourceData) mediatailor.UpdateSour
 	return updateParams
 }
 
-func deleteVodSources(sourceLocationName *string, client *mediatailor.MediaTailor) error {
-	vodSourcesList, err := client.ListVodSources(&mediatailor.ListVodSourcesInput{SourceLocationName: sourceLocationName})
+// getVodSourceNames returns the names of the VOD sources of the source location, from all the pages of the list.
+func getVodSourceNames(ctx context.Context, sourceLocationName *string, client mediatailoriface.MediaTailorAPI) ([]string, error) {
+	var names []string
+	err := client.ListVodSourcesPagesWithContext(ctx, &mediatailor.ListVodSourcesInput{SourceLocationName: sourceLocationName}, func(page *mediatailor.ListVodSourcesOutput, lastPage bool) bool {
+		for _, vodSource := range page.Items {
+			names = append(names, aws.StringValue(vodSource.VodSourceName))
+		}
+		return true
+	})
+	return names, err
+}
+
+// getLiveSourceNames returns the names of the live sources of the source location, from all the pages of the list.
+func getLiveSourceNames(ctx context.Context, sourceLocationName *string, client mediatailoriface.MediaTailorAPI) ([]string, error) {
+	var names []string
+	err := client.ListLiveSourcesPagesWithContext(ctx, &mediatailor.ListLiveSourcesInput{SourceLocationName: sourceLocationName}, func(page *mediatailor.ListLiveSourcesOutput, lastPage bool) bool {
+		for _, liveSource := range page.Items {
+			names = append(names, aws.StringValue(liveSource.LiveSourceName))
+		}
+		return true
+	})
+	return names, err
+}
+
+// deleteVodSources deletes the VOD sources of the source location. They are all listed before being deleted, so that
+// the deletions do not shift the pages of the list.
+func deleteVodSources(ctx context.Context, sourceLocationName *string, client mediatailoriface.MediaTailorAPI) error {
+	names, err := getVodSourceNames(ctx, sourceLocationName, client)
 	if err != nil {
 		return err
 	}
-	for _, vodSource := range vodSourcesList.Items {
-		if _, err := client.DeleteVodSource(&mediatailor.DeleteVodSourceInput{VodSourceName: vodSource.VodSourceName, SourceLocationName: sourceLocationName}); err != nil {
+	for _, name := range names {
+		if _, err := client.DeleteVodSourceWithContext(ctx, &mediatailor.DeleteVodSourceInput{VodSourceName: aws.String(name), SourceLocationName: sourceLocationName}); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func deleteLiveSources(sourceLocationName *string, client *mediatailor.MediaTailor) error {
-	liveSourcesList, err := client.ListLiveSources(&mediatailor.ListLiveSourcesInput{SourceLocationName: sourceLocationName})
+// deleteLiveSources deletes the live sources of the source location, like deleteVodSources.
+func deleteLiveSources(ctx context.Context, sourceLocationName *string, client mediatailoriface.MediaTailorAPI) error {
+	names, err := getLiveSourceNames(ctx, sourceLocationName, client)
 	if err != nil {
 		return err
 	}
-	for _, liveSource := range liveSourcesList.Items {
-		if _, err := client.DeleteLiveSource(&mediatailor.DeleteLiveSourceInput{LiveSourceName: liveSource.LiveSourceName, SourceLocationName: sourceLocationName}); err != nil {
+	for _, name := range names {
+		if _, err := client.DeleteLiveSourceWithContext(ctx, &mediatailor.DeleteLiveSourceInput{LiveSourceName: aws.String(name), SourceLocationName: sourceLocationName}); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// checkSourceLocationIsEmpty returns an error listing the VOD and live sources of the source location, if it has any.
+func checkSourceLocationIsEmpty(ctx context.Context, sourceLocationName *string, client mediatailoriface.MediaTailorAPI) error {
+	vodSources, err := getVodSourceNames(ctx, sourceLocationName, client)
+	if err != nil {
+		return fmt.Errorf("error while listing the VOD sources: %v", err)
+	}
+	liveSources, err := getLiveSourceNames(ctx, sourceLocationName, client)
+	if err != nil {
+		return fmt.Errorf("error while listing the live sources: %v", err)
+	}
+	var sources []string
+	if len(vodSources) > 0 {
+		sources = append(sources, "VOD sources: "+strings.Join(vodSources, ", "))
+	}
+	if len(liveSources) > 0 {
+		sources = append(sources, "live sources: "+strings.Join(liveSources, ", "))
+	}
+	if len(sources) > 0 {
+		return fmt.Errorf("the source location %s still contains sources (%s), delete them or set force_destroy to true to delete them with the source location", aws.StringValue(sourceLocationName), strings.Join(sources, "; "))
+	}
+	return nil
+}
+
+// importSourceLocationState imports source locations like importStateWithRegion, with the default value of
+// force_destroy, which is not returned by MediaTailor.
+func importSourceLocationState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("force_destroy", false); err != nil {
+		return nil, fmt.Errorf("error while setting force_destroy: %w", err)
+	}
+	return importStateWithRegion(ctx, d, meta)
+}
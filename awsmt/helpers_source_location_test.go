@@ -1,13 +1,15 @@
 package awsmt
 
 import (
+	"context"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/aws/aws-sdk-go/service/mediatailor/mediatailoriface"
 	"testing"
 )
 
-func setUpTestResources(sourceLocationName *string) (*mediatailor.MediaTailor, *mediatailor.HttpPackageConfiguration, error) {
-	conn := testAccProvider.Meta().(*mediatailor.MediaTailor)
+func setUpTestResources(sourceLocationName *string) (mediatailoriface.MediaTailorAPI, *mediatailor.HttpPackageConfiguration, error) {
+	conn := testAccProvider.Meta().(*providerMeta).client
 	httpConfiguration := &mediatailor.HttpConfiguration{BaseUrl: aws.String("https://www.example.com")}
 	if _, err := conn.CreateSourceLocation(&mediatailor.CreateSourceLocationInput{SourceLocationName: sourceLocationName, HttpConfiguration: httpConfiguration}); err != nil {
 		return nil, nil, err
@@ -29,7 +31,7 @@ func TestDeleteVodSources(t *testing.T) {
 		t.Fatalf(`Error creating vod source: %v`, err)
 	}
 	// act: delete vod sources
-	if err := deleteVodSources(sourceLocationName, conn); err != nil {
+	if err := deleteVodSources(context.Background(), sourceLocationName, conn); err != nil {
 		t.Fatalf(`Error deleting vod sources: %v`, err)
 	}
 	// assert: vod source has been deleted
@@ -44,10 +46,10 @@ func TestDeleteVodSources(t *testing.T) {
 
 func TestDeleteVodSourcesError(t *testing.T) {
 	// arrange: set up name and connection
-	conn := testAccProvider.Meta().(*mediatailor.MediaTailor)
+	conn := testAccProvider.Meta().(*providerMeta).client
 	sourceLocationName := aws.String("source_location_test_vod_deletion_error")
 	// act: delete vod sources
-	if err := deleteVodSources(sourceLocationName, conn); err == nil {
+	if err := deleteVodSources(context.Background(), sourceLocationName, conn); err == nil {
 		t.Fatalf(`Source location actually exists`)
 	}
 }
@@ -65,7 +67,7 @@ func TestDeleteLiveSources(t *testing.T) {
 		t.Fatalf(`Error creating live source: %v`, err)
 	}
 	// act: delete live sources
-	if err := deleteLiveSources(sourceLocationName, conn); err != nil {
+	if err := deleteLiveSources(context.Background(), sourceLocationName, conn); err != nil {
 		t.Fatalf(`Error deleting live sources: %v`, err)
 	}
 	// assert: live source has been deleted
@@ -80,10 +82,10 @@ func TestDeleteLiveSources(t *testing.T) {
 
 func TestDeleteLiveSourcesError(t *testing.T) {
 	// arrange: set up name and connection
-	conn := testAccProvider.Meta().(*mediatailor.MediaTailor)
+	conn := testAccProvider.Meta().(*providerMeta).client
 	sourceLocationName := aws.String("source_location_test_vod_deletion_error")
 	// act: delete live sources
-	if err := deleteLiveSources(sourceLocationName, conn); err == nil {
+	if err := deleteLiveSources(context.Background(), sourceLocationName, conn); err == nil {
 		t.Fatalf(`Source location actually exists`)
 	}
 }
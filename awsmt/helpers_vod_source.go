@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -16,38 +17,32 @@ func setHttpPackageConfigurations(values []*mediatailor.HttpPackageConfiguration
 		temp["type"] = c.Type
 		configurations = append(configurations, temp)
 	}
-	if err := d.Set("http_package_configurations", configurations); err != nil {
+	if err := setAttribute(d, "http_package_configurations", configurations); err != nil {
 		return fmt.Errorf("error while setting the http package configurations: %w", err)
 	}
 	return nil
 }
 
-func setVodSource(values *mediatailor.DescribeVodSourceOutput, d *schema.ResourceData) error {
+func setVodSource(values *mediatailor.DescribeVodSourceOutput, d *schema.ResourceData) diag.Diagnostics {
 	var errs []error
 
 	if values.Arn != nil {
-		errs = append(errs, d.Set("arn", values.Arn))
+		errs = append(errs, setAttribute(d, "arn", values.Arn))
 	}
 	if values.CreationTime != nil {
-		errs = append(errs, d.Set("creation_time", values.CreationTime.String()))
+		errs = append(errs, setAttribute(d, "creation_time", values.CreationTime.String()))
 	}
 	errs = append(errs, setHttpPackageConfigurations(values.HttpPackageConfigurations, d))
 	if values.LastModifiedTime != nil {
-		errs = append(errs, d.Set("last_modified_time", values.LastModifiedTime.String()))
+		errs = append(errs, setAttribute(d, "last_modified_time", values.LastModifiedTime.String()))
 	}
 	if values.SourceLocationName != nil {
-		errs = append(errs, d.Set("source_location_name", values.SourceLocationName))
+		errs = append(errs, setAttribute(d, "source_location_name", values.SourceLocationName))
 	}
-	errs = append(errs, d.Set("tags", values.Tags))
 	if values.VodSourceName != nil {
-		errs = append(errs, d.Set("name", values.VodSourceName))
+		errs = append(errs, setAttribute(d, "name", values.VodSourceName))
 	}
-	for _, e := range errs {
-		if e != nil {
-			return fmt.Errorf("the following error occured while setting the values: %w", e)
-		}
-	}
-	return nil
+	return diagnosticsFromErrors(errs)
 }
 
 func getCreateVodSourceInput(d *schema.ResourceData) mediatailor.CreateVodSourceInput {
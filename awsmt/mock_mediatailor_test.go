@@ -0,0 +1,207 @@
+package awsmt
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/aws/aws-sdk-go/service/mediatailor/mediatailoriface"
+	"time"
+)
+
+// mockMediaTailor is a MediaTailor client recording the operations called by the function under test, to test their
+// orchestration without a MediaTailor API. The operations that are not implemented panic, because the embedded
+// interface is nil.
+type mockMediaTailor struct {
+	mediatailoriface.MediaTailorAPI
+	// calls are the names of the called operations, in order.
+	calls []string
+	// errors are the errors returned by the operations, indexed by operation name.
+	errors map[string]error
+	// channelState is the state of the channel returned by DescribeChannel, updated by StartChannel and StopChannel.
+	channelState string
+	// transitionDelay is the number of DescribeChannel calls returning the previous state after a state change.
+	transitionDelay int
+	pendingState    string
+	pendingCalls    int
+	// tags are the tags of the channel returned by DescribeChannel.
+	tags map[string]*string
+	// policy is the policy of the channel returned by GetChannelPolicy, which returns a NotFoundException if it is empty.
+	policy string
+	// vodSources and liveSources are the names of the sources returned by the list operations.
+	vodSources  []string
+	liveSources []string
+	// pageSize is the number of sources in each page of the list operations, all the sources are in one page if 0.
+	pageSize int
+}
+
+func newMockMediaTailor(channelState string) *mockMediaTailor {
+	return &mockMediaTailor{errors: map[string]error{}, channelState: channelState}
+}
+
+func (m *mockMediaTailor) call(operation string) error {
+	m.calls = append(m.calls, operation)
+	return m.errors[operation]
+}
+
+// setChannelState changes the state of the channel, after transitionDelay calls to DescribeChannel.
+func (m *mockMediaTailor) setChannelState(state string) {
+	if m.transitionDelay == 0 {
+		m.channelState = state
+		return
+	}
+	m.pendingState = state
+	m.pendingCalls = m.transitionDelay
+}
+
+func (m *mockMediaTailor) channel(name *string) *mediatailor.DescribeChannelOutput {
+	return &mediatailor.DescribeChannelOutput{
+		Arn:              aws.String("arn:aws:mediatailor:eu-central-1:123456789012:channel/" + aws.StringValue(name)),
+		ChannelName:      name,
+		ChannelState:     aws.String(m.channelState),
+		CreationTime:     aws.Time(time.Unix(0, 0)),
+		LastModifiedTime: aws.Time(time.Unix(0, 0)),
+		PlaybackMode:     aws.String("LOOP"),
+		Tags:             m.tags,
+		Tier:             aws.String("BASIC"),
+	}
+}
+
+func (m *mockMediaTailor) CreateChannelWithContext(_ aws.Context, input *mediatailor.CreateChannelInput, _ ...request.Option) (*mediatailor.CreateChannelOutput, error) {
+	if err := m.call("CreateChannel"); err != nil {
+		return nil, err
+	}
+	return &mediatailor.CreateChannelOutput{Arn: m.channel(input.ChannelName).Arn, ChannelName: input.ChannelName, ChannelState: aws.String(m.channelState)}, nil
+}
+
+func (m *mockMediaTailor) DescribeChannelWithContext(_ aws.Context, input *mediatailor.DescribeChannelInput, _ ...request.Option) (*mediatailor.DescribeChannelOutput, error) {
+	if err := m.call("DescribeChannel"); err != nil {
+		return nil, err
+	}
+	if m.pendingCalls > 0 {
+		m.pendingCalls--
+	} else if m.pendingState != "" {
+		m.channelState = m.pendingState
+		m.pendingState = ""
+	}
+	return m.channel(input.ChannelName), nil
+}
+
+func (m *mockMediaTailor) UpdateChannelWithContext(_ aws.Context, input *mediatailor.UpdateChannelInput, _ ...request.Option) (*mediatailor.UpdateChannelOutput, error) {
+	if err := m.call("UpdateChannel"); err != nil {
+		return nil, err
+	}
+	return &mediatailor.UpdateChannelOutput{Arn: m.channel(input.ChannelName).Arn, ChannelName: input.ChannelName}, nil
+}
+
+func (m *mockMediaTailor) DeleteChannelWithContext(aws.Context, *mediatailor.DeleteChannelInput, ...request.Option) (*mediatailor.DeleteChannelOutput, error) {
+	return &mediatailor.DeleteChannelOutput{}, m.call("DeleteChannel")
+}
+
+func (m *mockMediaTailor) StartChannelWithContext(aws.Context, *mediatailor.StartChannelInput, ...request.Option) (*mediatailor.StartChannelOutput, error) {
+	if err := m.call("StartChannel"); err != nil {
+		return nil, err
+	}
+	m.setChannelState(mediatailor.ChannelStateRunning)
+	return &mediatailor.StartChannelOutput{}, nil
+}
+
+func (m *mockMediaTailor) StopChannelWithContext(aws.Context, *mediatailor.StopChannelInput, ...request.Option) (*mediatailor.StopChannelOutput, error) {
+	if err := m.call("StopChannel"); err != nil {
+		return nil, err
+	}
+	m.setChannelState(mediatailor.ChannelStateStopped)
+	return &mediatailor.StopChannelOutput{}, nil
+}
+
+func (m *mockMediaTailor) ConfigureLogsForChannelWithContext(aws.Context, *mediatailor.ConfigureLogsForChannelInput, ...request.Option) (*mediatailor.ConfigureLogsForChannelOutput, error) {
+	return &mediatailor.ConfigureLogsForChannelOutput{}, m.call("ConfigureLogsForChannel")
+}
+
+func (m *mockMediaTailor) GetChannelPolicyWithContext(aws.Context, *mediatailor.GetChannelPolicyInput, ...request.Option) (*mediatailor.GetChannelPolicyOutput, error) {
+	if err := m.call("GetChannelPolicy"); err != nil {
+		return nil, err
+	}
+	if m.policy == "" {
+		return nil, awserr.New("NotFoundException", "the channel has no policy", nil)
+	}
+	return &mediatailor.GetChannelPolicyOutput{Policy: aws.String(m.policy)}, nil
+}
+
+func (m *mockMediaTailor) PutChannelPolicyWithContext(aws.Context, *mediatailor.PutChannelPolicyInput, ...request.Option) (*mediatailor.PutChannelPolicyOutput, error) {
+	return &mediatailor.PutChannelPolicyOutput{}, m.call("PutChannelPolicy")
+}
+
+func (m *mockMediaTailor) DeleteChannelPolicyWithContext(aws.Context, *mediatailor.DeleteChannelPolicyInput, ...request.Option) (*mediatailor.DeleteChannelPolicyOutput, error) {
+	return &mediatailor.DeleteChannelPolicyOutput{}, m.call("DeleteChannelPolicy")
+}
+
+func (m *mockMediaTailor) TagResourceWithContext(aws.Context, *mediatailor.TagResourceInput, ...request.Option) (*mediatailor.TagResourceOutput, error) {
+	return &mediatailor.TagResourceOutput{}, m.call("TagResource")
+}
+
+func (m *mockMediaTailor) UntagResourceWithContext(aws.Context, *mediatailor.UntagResourceInput, ...request.Option) (*mediatailor.UntagResourceOutput, error) {
+	return &mediatailor.UntagResourceOutput{}, m.call("UntagResource")
+}
+
+// pages splits the names into pages of pageSize names.
+func (m *mockMediaTailor) pages(names []string) [][]string {
+	if m.pageSize == 0 || len(names) == 0 {
+		return [][]string{names}
+	}
+	var pages [][]string
+	for i := 0; i < len(names); i += m.pageSize {
+		end := i + m.pageSize
+		if end > len(names) {
+			end = len(names)
+		}
+		pages = append(pages, names[i:end])
+	}
+	return pages
+}
+
+func (m *mockMediaTailor) ListVodSourcesPagesWithContext(_ aws.Context, _ *mediatailor.ListVodSourcesInput, fn func(*mediatailor.ListVodSourcesOutput, bool) bool, _ ...request.Option) error {
+	pages := m.pages(m.vodSources)
+	for i, page := range pages {
+		if err := m.call("ListVodSources"); err != nil {
+			return err
+		}
+		output := &mediatailor.ListVodSourcesOutput{}
+		for _, name := range page {
+			output.Items = append(output.Items, &mediatailor.VodSource{VodSourceName: aws.String(name)})
+		}
+		if !fn(output, i == len(pages)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+func (m *mockMediaTailor) DeleteVodSourceWithContext(_ aws.Context, input *mediatailor.DeleteVodSourceInput, _ ...request.Option) (*mediatailor.DeleteVodSourceOutput, error) {
+	return &mediatailor.DeleteVodSourceOutput{}, m.call("DeleteVodSource " + aws.StringValue(input.VodSourceName))
+}
+
+func (m *mockMediaTailor) ListLiveSourcesPagesWithContext(_ aws.Context, _ *mediatailor.ListLiveSourcesInput, fn func(*mediatailor.ListLiveSourcesOutput, bool) bool, _ ...request.Option) error {
+	pages := m.pages(m.liveSources)
+	for i, page := range pages {
+		if err := m.call("ListLiveSources"); err != nil {
+			return err
+		}
+		output := &mediatailor.ListLiveSourcesOutput{}
+		for _, name := range page {
+			output.Items = append(output.Items, &mediatailor.LiveSource{LiveSourceName: aws.String(name)})
+		}
+		if !fn(output, i == len(pages)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+func (m *mockMediaTailor) DeleteLiveSourceWithContext(_ aws.Context, input *mediatailor.DeleteLiveSourceInput, _ ...request.Option) (*mediatailor.DeleteLiveSourceOutput, error) {
+	return &mediatailor.DeleteLiveSourceOutput{}, m.call("DeleteLiveSource " + aws.StringValue(input.LiveSourceName))
+}
+
+func (m *mockMediaTailor) DeleteSourceLocationWithContext(aws.Context, *mediatailor.DeleteSourceLocationInput, ...request.Option) (*mediatailor.DeleteSourceLocationOutput, error) {
+	return &mediatailor.DeleteSourceLocationOutput{}, m.call("DeleteSourceLocation")
+}
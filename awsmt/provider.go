@@ -3,17 +3,266 @@ package awsmt
 import (
 	"context"
 	"fmt"
-	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/aws/aws-sdk-go/service/mediatailor/mediatailoriface"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"os"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"strings"
+	"sync"
 )
 
+// @ADR
+// Context: The CRUD functions and their helpers used the concrete MediaTailor client of the SDK, so that their
+// orchestration logic, for example stopping a channel before updating it, could only be tested against AWS.
+// Decision: We decided to store the clients as mediatailoriface.MediaTailorAPI in the provider meta, and to make every
+// helper accept the interface, so that the unit tests can replace the client with a mock.
+// Consequences: The code needing the configuration of the client, for example its endpoint, has to type-assert it to
+// the concrete client or to read the configuration from the provider meta.
+
+// providerMeta holds the MediaTailor client and the provider level settings shared by every resource.
+type providerMeta struct {
+	client      mediatailoriface.MediaTailorAPI
+	region      string
+	accountId   string
+	defaultTags map[string]interface{}
+	ignoreTags  ignoreTagsConfig
+
+	newClient func(region string) mediatailoriface.MediaTailorAPI
+	mu        sync.Mutex
+	clients   map[string]mediatailoriface.MediaTailorAPI
+}
+
+// clientForRegion returns the MediaTailor client of the given region, or the client of the provider region if the
+// region is empty. The clients of the other regions are created on first use and cached.
+func (m *providerMeta) clientForRegion(region string) mediatailoriface.MediaTailorAPI {
+	if region == "" || region == m.region {
+		return m.client
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c, ok := m.clients[region]; ok {
+		return c
+	}
+	if m.clients == nil {
+		m.clients = map[string]mediatailoriface.MediaTailorAPI{}
+	}
+	m.clients[region] = m.newClient(region)
+	return m.clients[region]
+}
+
+// ignoreTagsConfig holds the tag keys and key prefixes that the resources ignore, because they are managed outside
+// Terraform.
+type ignoreTagsConfig struct {
+	keys        []string
+	keyPrefixes []string
+}
+
+func (c ignoreTagsConfig) ignores(key string) bool {
+	for _, k := range c.keys {
+		if key == k {
+			return true
+		}
+	}
+	for _, p := range c.keyPrefixes {
+		if strings.HasPrefix(key, p) {
+			return true
+		}
+	}
+	return false
+}
+
 func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
+			"access_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"secret_key"},
+				Description:  "The AWS access key. Must be specified together with secret_key.",
+			},
+			"allowed_account_ids": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"forbidden_account_ids"},
+				Description:   "The IDs of the only AWS accounts the provider is allowed to manage.",
+			},
+			"assume_role": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Configuration block for the IAM role to assume before calling MediaTailor.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"duration": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateAssumeRoleDuration,
+							Description:  "The duration of the role session, between 15m and 12h. Defaults to 15m.",
+						},
+						"external_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The external identifier to use when assuming the role.",
+						},
+						"policy": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsJSON,
+							Description:  "The IAM policy, in JSON format, further restricting the permissions of the role session.",
+						},
+						"policy_arns": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The ARNs of the IAM managed policies further restricting the permissions of the role session.",
+						},
+						"role_arn": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ARN of the IAM role to assume.",
+						},
+						"session_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of the role session.",
+						},
+						"tags": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The session tags to apply to the role session.",
+						},
+						"transitive_tag_keys": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The keys of the session tags to pass to the roles assumed later in a role chain.",
+						},
+					},
+				},
+			},
+			"assume_role_with_web_identity": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Configuration block for the IAM role to assume with an OpenID Connect token before calling MediaTailor.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"duration": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateAssumeRoleDuration,
+							Description:  "The duration of the role session, between 15m and 12h. Defaults to 15m.",
+						},
+						"policy_arns": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The ARNs of the IAM managed policies further restricting the permissions of the role session.",
+						},
+						"role_arn": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ARN of the IAM role to assume.",
+						},
+						"session_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of the role session.",
+						},
+						"web_identity_token": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							Sensitive:     true,
+							ConflictsWith: []string{"assume_role_with_web_identity.0.web_identity_token_file"},
+							Description:   "The OpenID Connect token issued by the identity provider.",
+						},
+						"web_identity_token_file": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The path of the file containing the OpenID Connect token issued by the identity provider.",
+						},
+					},
+				},
+			},
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Configuration block with settings to default resource tags across all resources.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Resource tags to default across all resources. Tags declared on a resource override them.",
+						},
+					},
+				},
+			},
+			"endpoints": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Configuration block with the custom endpoints of the AWS services used by the provider.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mediatailor": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+							Description:  "The custom endpoint of the MediaTailor API.",
+						},
+						"sts": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+							Description:  "The custom endpoint of the STS API, used to assume roles and to validate the credentials.",
+						},
+					},
+				},
+			},
+			"forbidden_account_ids": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"allowed_account_ids"},
+				Description:   "The IDs of the AWS accounts the provider is not allowed to manage.",
+			},
+			"ignore_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Configuration block with settings to ignore resource tags across all resources.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key_prefixes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Resource tag key prefixes to ignore across all resources.",
+						},
+						"keys": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Resource tag keys to ignore across all resources.",
+						},
+					},
+				},
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      25,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of times a MediaTailor request is retried after a throttling or a transient server error. Defaults to 25.",
+			},
 			"profile": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -23,56 +272,113 @@ func Provider() *schema.Provider {
 			"region": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "AWS region. defaults to 'eu-central-1'.",
+				Description: "AWS region. Defaults to the AWS_REGION or AWS_DEFAULT_REGION environment variables, then to 'eu-central-1'.",
+			},
+			"retry_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      retryModeStandard,
+				ValidateFunc: validation.StringInSlice([]string{retryModeStandard, retryModeAdaptive}, false),
+				Description:  "The retry mode of the MediaTailor requests, either 'standard' or 'adaptive'. In the adaptive mode, every request is delayed after a throttling error. Defaults to 'standard'.",
+			},
+			"secret_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"access_key"},
+				Description:  "The AWS secret key. Must be specified together with access_key.",
+			},
+			"shared_config_files": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The paths of the shared config files. Defaults to '~/.aws/config'.",
+			},
+			"shared_credentials_files": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The paths of the shared credentials files. Defaults to '~/.aws/credentials'.",
+			},
+			"skip_credentials_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to skip the validation of the credentials with the STS API.",
+			},
+			"skip_requesting_account_id": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to skip requesting the account ID with the STS API.",
+			},
+			"token": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"access_key"},
+				Description:  "The session token of temporary AWS credentials, used together with access_key and secret_key.",
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"awsmt_playback_configuration": resourcePlaybackConfiguration(),
-			"awsmt_channel":                resourceChannel(),
-			"awsmt_source_location":        resourceSourceLocation(),
-			"awsmt_vod_source":             resourceVodSource(),
-			"awsmt_live_source":            resourceLiveSource(),
+			"awsmt_playback_configuration":         resourcePlaybackConfiguration(),
+			"awsmt_channel":                        resourceChannel(),
+			"awsmt_channel_policy":                 resourceChannelPolicy(),
+			"awsmt_source_location":                resourceSourceLocation(),
+			"awsmt_vod_source":                     resourceVodSource(),
+			"awsmt_live_source":                    resourceLiveSource(),
+			"awsmt_prefetch_schedule":              resourcePrefetchSchedule(),
+			"awsmt_program":                        resourceProgram(),
+			"awsmt_playback_configuration_logging": resourcePlaybackConfigurationLogging(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"awsmt_playback_configuration": dataSourcePlaybackConfiguration(),
-			"awsmt_channel":                dataSourceChannel(),
-			"awsmt_source_location":        dataSourceSourceLocation(),
-			"awsmt_vod_source":             dataSourceVodSource(),
-			"awsmt_live_source":            dataSourceLiveSource(),
+			"awsmt_playback_configuration":  dataSourcePlaybackConfiguration(),
+			"awsmt_channel":                 dataSourceChannel(),
+			"awsmt_source_location":         dataSourceSourceLocation(),
+			"awsmt_vod_source":              dataSourceVodSource(),
+			"awsmt_live_source":             dataSourceLiveSource(),
+			"awsmt_channels":                dataSourceChannels(),
+			"awsmt_playback_configurations": dataSourcePlaybackConfigurations(),
+			"awsmt_source_locations":        dataSourceSourceLocations(),
+			"awsmt_vod_sources":             dataSourceVodSources(),
+			"awsmt_live_sources":            dataSourceLiveSources(),
+			"awsmt_channel_schedule":        dataSourceChannelSchedule(),
+			"awsmt_alerts":                  dataSourceAlerts(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	region, ok := d.GetOk("region")
-	if !ok {
-		region = "eu-central-1"
-	}
+	region := getRegion(d)
 
-	var sess *session.Session
-	var err error
-	var profile = ""
-
-	if val := d.Get("profile"); val.(string) != "" {
-		profile = val.(string)
-	} else if env := os.Getenv("AWS_PROFILE"); env != "" {
-		profile = os.Getenv("AWS_PROFILE")
+	options, sources, err := getSessionOptions(d, region)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid credentials configuration",
+			Detail:   err.Error(),
+		})
+		return nil, diags
 	}
 
-	if profile != "" {
-		sess, err = session.NewSessionWithOptions(session.Options{
-			SharedConfigState: session.SharedConfigEnable,
-			Config: aws.Config{
-				Region: aws.String(region.(string)),
-			},
-			Profile: profile,
+	// @ADR
+	// Context: Several credential sources can be configured at the same time, for example a profile in the provider
+	// configuration and access keys in the environment, and it is hard to tell which one is used.
+	// Decision: We decided to always log the credential source in use, and to warn the user when lower precedence
+	// sources are ignored.
+	// Consequences: Users relying on the precedence order on purpose get a warning on every run.
+	tflog.Info(ctx, "Configuring the MediaTailor client", map[string]interface{}{"region": region, "credential_source": sources[0]})
+	if len(sources) > 1 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Using the credentials from the %s", sources[0]),
+			Detail:   fmt.Sprintf("The following credential sources are also configured, but are ignored because of their lower precedence: %s.", strings.Join(sources[1:], ", ")),
 		})
-	} else {
-		sess, err = session.NewSession(&aws.Config{Region: aws.String(region.(string))})
 	}
 
+	sess, err := session.NewSessionWithOptions(options)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -81,6 +387,66 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 		})
 		return nil, diags
 	}
-	c := mediatailor.New(sess)
-	return c, diags
+
+	if err := configureAssumeRoleCredentials(sess, d); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to assume the IAM role",
+			Detail:   err.Error(),
+		})
+		return nil, diags
+	}
+
+	accountId, err := getAccountId(ctx, sess, d)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to validate the credentials",
+			Detail:   err.Error(),
+		})
+		return nil, diags
+	}
+
+	if err := checkAccountId(d, accountId); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Account not allowed",
+			Detail:   err.Error(),
+		})
+		return nil, diags
+	}
+
+	newClient := getMediaTailorClientFactory(sess, d)
+	return &providerMeta{
+		client:      newClient(region),
+		region:      region,
+		accountId:   accountId,
+		defaultTags: getDefaultTags(d),
+		ignoreTags:  getIgnoreTags(d),
+		newClient:   newClient,
+	}, diags
+}
+
+func getDefaultTags(d *schema.ResourceData) map[string]interface{} {
+	if v, ok := d.GetOk("default_tags"); ok && v.([]interface{})[0] != nil {
+		val := v.([]interface{})[0].(map[string]interface{})
+		if tags, ok := val["tags"]; ok {
+			return tags.(map[string]interface{})
+		}
+	}
+	return map[string]interface{}{}
+}
+
+func getIgnoreTags(d *schema.ResourceData) ignoreTagsConfig {
+	var c ignoreTagsConfig
+	if v, ok := d.GetOk("ignore_tags"); ok && v.([]interface{})[0] != nil {
+		val := v.([]interface{})[0].(map[string]interface{})
+		for _, k := range val["keys"].(*schema.Set).List() {
+			c.keys = append(c.keys, k.(string))
+		}
+		for _, p := range val["key_prefixes"].(*schema.Set).List() {
+			c.keyPrefixes = append(c.keyPrefixes, p.(string))
+		}
+	}
+	return c
 }
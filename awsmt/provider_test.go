@@ -1,15 +1,25 @@
 package awsmt
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"net/http/httptest"
+	"os"
 	"testing"
 )
 
+// fakeBackendEnvVar is the environment variable that runs the tests against the in-memory fake MediaTailor API
+// instead of AWS.
+const fakeBackendEnvVar = "AWSMT_FAKE_BACKEND"
+
 //var testAccProviders map[string]*schema.Provider
 //var testAccProvider *schema.Provider
 
@@ -17,9 +27,44 @@ var ProviderFactories map[string]func() (*schema.Provider, error)
 var testAccProvider *schema.Provider
 
 func TestMain(m *testing.M) {
+	if isFakeBackend() {
+		server := httptest.NewServer(newFakeMediaTailor())
+		if err := useFakeBackend(testAccProvider, server.URL); err != nil {
+			fmt.Fprintf(os.Stderr, "unable to configure the provider for the fake MediaTailor API: %v\n", err)
+			os.Exit(1)
+		}
+	}
 	resource.TestMain(m)
 }
 
+func isFakeBackend() bool {
+	return os.Getenv(fakeBackendEnvVar) != ""
+}
+
+// useFakeBackend points every configuration of the provider to the fake MediaTailor API, with static credentials, and
+// configures the provider once for the unit tests that use its client without running an acceptance test first.
+func useFakeBackend(p *schema.Provider, endpoint string) error {
+	configure := p.ConfigureContextFunc
+	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		settings := map[string]interface{}{
+			"access_key": "test",
+			"secret_key": "test",
+			"endpoints":  []interface{}{map[string]interface{}{"mediatailor": endpoint, "sts": endpoint}},
+		}
+		for k, v := range settings {
+			if err := d.Set(k, v); err != nil {
+				return nil, diag.FromErr(err)
+			}
+		}
+		return configure(ctx, d)
+	}
+
+	if diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(nil)); diags.HasError() {
+		return fmt.Errorf("%v", diags[0].Summary)
+	}
+	return nil
+}
+
 func sharedClientForRegion(region string) (interface{}, error) {
 	sess, err := session.NewSession(&aws.Config{Region: aws.String(region)})
 	if err != nil {
@@ -5,12 +5,13 @@ import (
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"regexp"
-	"strings"
+	"time"
 )
 
 func resourceChannel() *schema.Resource {
@@ -20,9 +21,26 @@ func resourceChannel() *schema.Resource {
 		UpdateContext: resourceChannelUpdate,
 		DeleteContext: resourceChannelDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importChannelState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
+			// @ADR
+			// Context: Outputs and filler slates can only be updated while the channel is stopped, so updating them takes
+			// a running channel off-air until it is restarted.
+			// Decision: We decided to fail the plan of such updates on running channels unless the allow_stop_for_update
+			// argument is set, and to only stop the channel when these attributes change.
+			// Consequences: The argument is not part of the SDK and is only stored in the state.
+			"allow_stop_for_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"arn":  &computedString,
 			"name": &requiredString,
 			// @ADR
@@ -46,6 +64,23 @@ func resourceChannel() *schema.Resource {
 				"vod_source_name":      &optionalString,
 			}),
 			"last_modified_time": &computedString,
+			"log_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"log_types": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{"AS_RUN"}, false),
+							},
+						},
+					},
+				},
+			},
 			// @ADR
 			// Context: The resource needs to support a list of configuration objects called "outputs", that would include
 			// several nested objects.
@@ -100,6 +135,8 @@ func resourceChannel() *schema.Resource {
 			// increasing the chances of error. Also, and the policy requires the developer to specify the ARN for the channel
 			// it refers to, even if it is not known while declaring the resource, forcing the developer to create the
 			// ARN themselves using the account ID and resource name.
+			// Update: The awsmt_channel_policy resource can be used instead of this attribute, the policy is therefore
+			// only read by the channel resource if it is declared in its configuration.
 			"policy": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -108,7 +145,9 @@ func resourceChannel() *schema.Resource {
 					return re.ReplaceAllString(old, "") == re.ReplaceAllString(new, "")
 				},
 			},
-			"tags": &optionalTags,
+			"region":   &optionalRegion,
+			"tags":     &optionalTags,
+			"tags_all": &computedTags,
 			"tier": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -117,25 +156,34 @@ func resourceChannel() *schema.Resource {
 		},
 		CustomizeDiff: customdiff.Sequence(
 			customdiff.ForceNewIfChange("name", func(ctx context.Context, old, new, meta interface{}) bool { return old.(string) != new.(string) }),
+			setTagsDiff,
+			checkChannelStopForUpdate,
 		),
 	}
 }
 
 func resourceChannelCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
+	client := getClient(d, meta)
 
 	var params = getCreateChannelInput(d)
+	params.Tags = getTagsAll(d, meta)
 
-	channel, err := client.CreateChannel(&params)
+	channel, err := client.CreateChannelWithContext(ctx, &params)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while creating the channel: %v", err))
 	}
 
-	if err := checkStatusAndStartChannel(client, d); err != nil {
+	if _, ok := d.GetOk("log_configuration"); ok {
+		if err := configureLogsForChannel(ctx, client, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := checkStatusAndStartChannel(ctx, client, d); err != nil {
 		return diag.FromErr(fmt.Errorf("error while starting the channel: %v", err))
 	}
 
-	if err := createChannelPolicy(client, d); err != nil {
+	if err := createChannelPolicy(ctx, client, d); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -144,101 +192,120 @@ func resourceChannelCreate(ctx context.Context, d *schema.ResourceData, meta int
 	return resourceChannelRead(ctx, d, meta)
 }
 
-func resourceChannelRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
+func resourceChannelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	if err := setRegion(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
 	var resourceName *string
 	resourceName, err := getResourceName(d, "name")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	res, err := client.DescribeChannel(&mediatailor.DescribeChannelInput{ChannelName: resourceName})
+	res, err := client.DescribeChannelWithContext(ctx, &mediatailor.DescribeChannelInput{ChannelName: resourceName})
+	if isNotFound(err) && !d.IsNewResource() {
+		tflog.Warn(ctx, "The channel was not found, removing it from the state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while retrieving the channel: %v", err))
 	}
-	err = setChannel(res, d)
-	if err != nil {
-		diag.FromErr(err)
+	if diags := setChannel(res, d); diags.HasError() {
+		return diags
 	}
-
-	policy, err := client.GetChannelPolicy(&mediatailor.GetChannelPolicyInput{ChannelName: resourceName})
-	if err != nil && !strings.Contains(err.Error(), "NotFound") {
-		return diag.FromErr(fmt.Errorf("error while getting the channel policy: %v", err))
+	if diags := setTagsAndTagsAll(d, meta, res.Tags); diags.HasError() {
+		return diags
 	}
-	if err := setChannelPolicy(policy, d); err != nil {
-		diag.FromErr(err)
+
+	// the policy is only read if it is managed by this resource, so that it does not conflict with awsmt_channel_policy,
+	// a policy deleted outside of Terraform is read as an empty policy
+	if _, ok := d.GetOk("policy"); ok {
+		policy, err := client.GetChannelPolicyWithContext(ctx, &mediatailor.GetChannelPolicyInput{ChannelName: resourceName})
+		if err != nil && !isNotFound(err) {
+			return diag.FromErr(fmt.Errorf("error while getting the channel policy: %v", err))
+		}
+		if err := setChannelPolicy(policy, d); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return nil
 }
 
 func resourceChannelUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
+	client := getClient(d, meta)
 
 	resourceName := d.Get("name").(string)
 
-	if d.HasChange("tags") {
-		oldValue, newValue := d.GetChange("tags")
-		res, err := client.DescribeChannel(&mediatailor.DescribeChannelInput{ChannelName: &resourceName})
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		if err := updateTags(client, res.Arn, oldValue, newValue); err != nil {
+	res, err := client.DescribeChannelWithContext(ctx, &mediatailor.DescribeChannelInput{ChannelName: &resourceName})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("tags", "tags_all") {
+		if err := updateTagsAll(ctx, client, res.Arn, d, meta); err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
-	res, err := client.DescribeChannel(&mediatailor.DescribeChannelInput{ChannelName: &resourceName})
-	if err != nil {
-		return diag.FromErr(err)
+	running := aws.StringValue(res.ChannelState) == mediatailor.ChannelStateRunning
+	newStatus := aws.StringValue(res.ChannelState)
+	if v, ok := d.GetOk("channel_state"); ok {
+		newStatus = v.(string)
 	}
-	previousStatus := res.ChannelState
-	newStatusFromSchema := ""
-	if *previousStatus == "RUNNING" {
-		if err := stopChannel(client, resourceName); err != nil {
+	requiresStop := d.HasChanges(channelStopAttributes...)
+
+	if running && (requiresStop || newStatus == mediatailor.ChannelStateStopped) {
+		if err := stopChannel(ctx, client, resourceName, d.Timeout(schema.TimeoutUpdate)); err != nil {
 			return diag.FromErr(err)
 		}
-	}
-	if _, ok := d.GetOk("channel_state"); ok {
-		_, newValue := d.GetChange("channel_state")
-		newStatusFromSchema = newValue.(string)
+		running = false
 	}
 
-	if err := updatePolicy(client, d, &resourceName); err != nil {
+	if err := updatePolicy(ctx, client, d, &resourceName); err != nil {
 		return diag.FromErr(err)
 	}
 
-	var params = getUpdateChannelInput(d)
-	channel, err := client.UpdateChannel(&params)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while updating the channel: %v", err))
+	if d.HasChange("log_configuration") {
+		if err := configureLogsForChannel(ctx, client, d); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
-	if (*previousStatus == "RUNNING" || newStatusFromSchema == "RUNNING") && newStatusFromSchema != "STOPPED" {
-		if err := startChannel(client, resourceName); err != nil {
-			return diag.FromErr(err)
+	if requiresStop {
+		var params = getUpdateChannelInput(d)
+		if _, err := client.UpdateChannelWithContext(ctx, &params); err != nil {
+			return diag.FromErr(fmt.Errorf("error while updating the channel: %v", err))
 		}
 	}
 
-	d.SetId(aws.StringValue(channel.Arn))
+	if !running && newStatus == mediatailor.ChannelStateRunning {
+		if err := startChannel(ctx, client, resourceName, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	return resourceChannelRead(ctx, d, meta)
 }
 
-func resourceChannelDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
+func resourceChannelDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
 
-	_, err := client.StopChannel(&mediatailor.StopChannelInput{ChannelName: aws.String(d.Get("name").(string))})
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while stopping the channel: %v", err))
+	if err := stopChannel(ctx, client, d.Get("name").(string), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
 	}
 
-	_, err = client.DeleteChannelPolicy(&mediatailor.DeleteChannelPolicyInput{ChannelName: aws.String(d.Get("name").(string))})
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while deleting the channel policy: %v", err))
+	// the policy is only deleted if it is managed by this resource, an awsmt_channel_policy resource deletes it otherwise
+	if _, ok := d.GetOk("policy"); ok {
+		_, err := client.DeleteChannelPolicyWithContext(ctx, &mediatailor.DeleteChannelPolicyInput{ChannelName: aws.String(d.Get("name").(string))})
+		if err != nil && !isNotFound(err) {
+			return diag.FromErr(fmt.Errorf("error while deleting the channel policy: %v", err))
+		}
 	}
 
-	_, err = client.DeleteChannel(&mediatailor.DeleteChannelInput{ChannelName: aws.String(d.Get("name").(string))})
+	_, err := client.DeleteChannelWithContext(ctx, &mediatailor.DeleteChannelInput{ChannelName: aws.String(d.Get("name").(string))})
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while deleting the resource: %v", err))
 	}
@@ -0,0 +1,122 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"regexp"
+	"time"
+)
+
+func resourceChannelPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceChannelPolicyCreate,
+		ReadContext:   resourceChannelPolicyRead,
+		UpdateContext: resourceChannelPolicyUpdate,
+		DeleteContext: resourceChannelPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateWithRegion,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		// @ADR
+		// Context: The policy embedded in the channel resource requires the developer to build the ARN of the channel
+		// before the channel exists.
+		// Decision: We decided to offer a standalone channel policy resource, identified by the channel name, next to
+		// the embedded policy attribute, and to refuse to create it if the channel already has a policy.
+		// Consequences: A channel policy should be managed either by the channel resource or by the channel policy
+		// resource, never by both.
+		Schema: map[string]*schema.Schema{
+			"channel_name": &requiredString,
+			"policy": {
+				Type:     schema.TypeString,
+				Required: true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					re := regexp.MustCompile(`\s?|\r?|\n?`)
+					return re.ReplaceAllString(old, "") == re.ReplaceAllString(new, "")
+				},
+			},
+			"region": &optionalRegion,
+		},
+		CustomizeDiff: customdiff.Sequence(
+			customdiff.ForceNewIfChange("channel_name", func(ctx context.Context, old, new, meta interface{}) bool { return old.(string) != new.(string) }),
+		),
+	}
+}
+
+func resourceChannelPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	channelName := d.Get("channel_name").(string)
+
+	existing, err := client.GetChannelPolicyWithContext(ctx, &mediatailor.GetChannelPolicyInput{ChannelName: aws.String(channelName)})
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(fmt.Errorf("error while getting the channel policy: %v", err))
+	}
+	if err == nil && aws.StringValue(existing.Policy) != "" {
+		return diag.Errorf("the channel '%s' already has a policy. Remove the policy attribute from the awsmt_channel resource or import the existing policy with 'terraform import'", channelName)
+	}
+
+	_, err = client.PutChannelPolicyWithContext(ctx, &mediatailor.PutChannelPolicyInput{ChannelName: aws.String(channelName), Policy: aws.String(d.Get("policy").(string))})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while creating the channel policy: %v", err))
+	}
+	d.SetId(channelName)
+
+	return resourceChannelPolicyRead(ctx, d, meta)
+}
+
+func resourceChannelPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	if err := setRegion(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := client.GetChannelPolicyWithContext(ctx, &mediatailor.GetChannelPolicyInput{ChannelName: aws.String(d.Id())})
+	if isNotFound(err) && !d.IsNewResource() {
+		tflog.Warn(ctx, "The channel policy was not found, removing it from the state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while getting the channel policy: %v", err))
+	}
+
+	if err := d.Set("channel_name", d.Id()); err != nil {
+		return diag.FromErr(fmt.Errorf("error while setting the channel name: %v", err))
+	}
+	if err := setChannelPolicy(res, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceChannelPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+
+	if err := updateChannelPolicy(ctx, client, d, aws.String(d.Id())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceChannelPolicyRead(ctx, d, meta)
+}
+
+func resourceChannelPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+
+	_, err := client.DeleteChannelPolicyWithContext(ctx, &mediatailor.DeleteChannelPolicyInput{ChannelName: aws.String(d.Id())})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while deleting the resource: %v", err))
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package awsmt
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
+	"testing"
+)
+
+func init() {
+	resource.AddTestSweepers("test_channel_policy", &resource.Sweeper{
+		Name: "test_channel_policy",
+		F: func(region string) error {
+			client, err := sharedClientForRegion(region)
+			if err != nil {
+				return fmt.Errorf("error getting client: %s", err)
+			}
+			conn := client.(*mediatailor.MediaTailor)
+			names := []string{"channel_policy_standalone", "channel_policy_conflict"}
+			for _, n := range names {
+				_, err = conn.DeleteChannelPolicy(&mediatailor.DeleteChannelPolicyInput{ChannelName: &n})
+				if err != nil {
+					if !isNotFound(err) {
+						return err
+					}
+				}
+				_, err = conn.DeleteChannel(&mediatailor.DeleteChannelInput{ChannelName: &n})
+				if err != nil {
+					if !isNotFound(err) {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccChannelPolicyResource_basic(t *testing.T) {
+	channelName := "channel_policy_standalone"
+	resourceName := "awsmt_channel_policy.test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckChannelPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccChannelPolicyConfig(channelName, "mediatailor:GetManifest"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", channelName),
+					resource.TestCheckResourceAttr(resourceName, "channel_name", channelName),
+					resource.TestMatchResourceAttr(resourceName, "policy", regexp.MustCompile(`mediatailor:GetManifest`)),
+					resource.TestCheckResourceAttr("awsmt_channel.test", "policy", ""),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      testAccChannelPolicyConfig(channelName, "mediatailor:GetChannelSchedule"),
+				ExpectError: regexp.MustCompile(`The following action names are invalid:`),
+			},
+		},
+	})
+}
+
+func TestAccChannelPolicyResource_conflict(t *testing.T) {
+	channelName := "channel_policy_conflict"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckChannelPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccChannelPolicyConfig_Conflict(channelName),
+				ExpectError: regexp.MustCompile(`already has a policy`),
+			},
+		},
+	})
+}
+
+func testAccCheckChannelPolicyDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*providerMeta).client
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "awsmt_channel_policy" {
+			continue
+		}
+
+		_, err := conn.GetChannelPolicy(&mediatailor.GetChannelPolicyInput{ChannelName: aws.String(rs.Primary.ID)})
+
+		if err != nil && isNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("the policy of the channel %s still exists", rs.Primary.ID)
+	}
+	return nil
+}
+
+func testAccChannelPolicyConfig(channelName, action string) string {
+	return fmt.Sprintf(`
+resource "awsmt_channel" "test" {
+  name = "%[1]s"
+  outputs {
+    manifest_name                = "default"
+    source_group                 = "default"
+    hls_manifest_windows_seconds = 30
+  }
+  playback_mode = "LOOP"
+  tier          = "BASIC"
+}
+
+resource "awsmt_channel_policy" "test" {
+  channel_name = awsmt_channel.test.name
+  policy       = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"%[2]s\", \"Resource\": \"${awsmt_channel.test.arn}\"}]}"
+}
+`, channelName, action)
+}
+
+func testAccChannelPolicyConfig_Conflict(channelName string) string {
+	return fmt.Sprintf(`
+resource "awsmt_channel" "test" {
+  name = "%[1]s"
+  outputs {
+    manifest_name                = "default"
+    source_group                 = "default"
+    hls_manifest_windows_seconds = 30
+  }
+  playback_mode = "LOOP"
+  policy        = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"mediatailor:GetManifest\", \"Resource\": \"*\"}]}"
+  tier          = "BASIC"
+}
+
+resource "awsmt_channel_policy" "test" {
+  channel_name = awsmt_channel.test.name
+  policy       = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"mediatailor:GetManifest\", \"Resource\": \"${awsmt_channel.test.arn}\"}]}"
+}
+`, channelName)
+}
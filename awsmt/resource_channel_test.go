@@ -22,11 +22,11 @@ func init() {
 				return fmt.Errorf("error getting client: %s", err)
 			}
 			conn := client.(*mediatailor.MediaTailor)
-			names := []string{"channel_test_basic", "channel_test_recreate", "channel_test_conflict", "channel_test_validate_tier", "channel_validate_playback_mode", "channel_update", "channel_tags", "linear_channel", "channel_policy", "basic_channel"}
+			names := []string{"channel_test_basic", "channel_test_recreate", "channel_test_conflict", "channel_test_validate_tier", "channel_validate_playback_mode", "channel_update", "channel_tags", "linear_channel", "channel_policy", "basic_channel", "channel_log_configuration", "channel_default_tags", "channel_ignore_tags"}
 			for _, n := range names {
 				_, err = conn.DeleteChannel(&mediatailor.DeleteChannelInput{ChannelName: &n})
 				if err != nil {
-					if !strings.Contains(err.Error(), "NotFound") {
+					if !isNotFound(err) {
 						return err
 					}
 				}
@@ -222,6 +222,100 @@ func TestAccChannelResource_tags(t *testing.T) {
 	})
 }
 
+func TestAccChannelResource_defaultTags(t *testing.T) {
+	rName := "channel_default_tags"
+	resourceName := "awsmt_channel.test"
+	// the provider is shared by every test, so the default tags must not be configured while other tests are running
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckChannelDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccChannelConfig_DefaultTags(rName, "default", "a", "b"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.a", "b"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.a", "b"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.Environment", "default"),
+				),
+			},
+			{
+				Config: testAccChannelConfig_DefaultTags(rName, "default", "Environment", "override"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "override"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.Environment", "override"),
+				),
+			},
+			{
+				Config: testAccChannelConfig_DefaultTags(rName, "updated", "a", "b"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.Environment", "updated"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccChannelResource_ignoreTags(t *testing.T) {
+	rName := "channel_ignore_tags"
+	resourceName := "awsmt_channel.test"
+	// the provider is shared by every test, so the ignored tags must not be configured while other tests are running
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckChannelDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccChannelConfig_IgnoreTags(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "1"),
+				),
+			},
+			{
+				PreConfig: func() {
+					conn := testAccProvider.Meta().(*providerMeta).client
+					res, err := conn.DescribeChannel(&mediatailor.DescribeChannelInput{ChannelName: aws.String(rName)})
+					if err != nil {
+						t.Fatal(err)
+					}
+					_, err = conn.TagResource(&mediatailor.TagResourceInput{ResourceArn: res.Arn, Tags: map[string]*string{"billing:team": aws.String("video"), "external": aws.String("true")}})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccChannelConfig_IgnoreTags(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "1"),
+					testAccCheckChannelTag(rName, "billing:team", "video"),
+					testAccCheckChannelTag(rName, "external", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckChannelTag(channelName, key, value string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		conn := testAccProvider.Meta().(*providerMeta).client
+		res, err := conn.DescribeChannel(&mediatailor.DescribeChannelInput{ChannelName: aws.String(channelName)})
+		if err != nil {
+			return err
+		}
+		if actual := aws.StringValue(res.Tags[key]); actual != value {
+			return fmt.Errorf("expected the tag %s of the channel to be '%s', got '%s'", key, value, actual)
+		}
+		return nil
+	}
+}
+
 func TestAccChannelResource_linear(t *testing.T) {
 	channelName := "linear_channel"
 	vodSourceName := "vod_source_channel"
@@ -262,6 +356,12 @@ func TestAccChannelResource_policy(t *testing.T) {
 					resource.TestMatchResourceAttr(resourceName, "policy", regexp.MustCompile(`mediatailor:GetManifest`)),
 				),
 			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"policy"},
+			},
 			{
 				Config:      testAccChannelConfig_Policy(channelName, "mediatailor:GetChannelSchedule", region, accountId),
 				ExpectError: regexp.MustCompile(`The following action names are invalid:`),
@@ -308,8 +408,66 @@ func TestAccChannelResource_stopAndDelete(t *testing.T) {
 	})
 }
 
+func TestAccChannelResource_allowStopForUpdate(t *testing.T) {
+	rName := "channel_allow_stop"
+	resourceName := "awsmt_channel.test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckChannelDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccChannelConfig_AllowStopForUpdate(rName, 30, false),
+				Check:  resource.TestCheckResourceAttr(resourceName, "channel_state", "RUNNING"),
+			},
+			{
+				Config:      testAccChannelConfig_AllowStopForUpdate(rName, 60, false),
+				ExpectError: regexp.MustCompile("set allow_stop_for_update to true to allow it"),
+			},
+			{
+				Config: testAccChannelConfig_AllowStopForUpdate(rName, 60, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "channel_state", "RUNNING"),
+					resource.TestCheckResourceAttr(resourceName, "outputs.0.hls_manifest_windows_seconds", "60"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccChannelResource_logConfiguration(t *testing.T) {
+	rName := "channel_log_configuration"
+	resourceName := "awsmt_channel.test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckChannelDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccChannelConfig_LogConfiguration(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "log_configuration.0.log_types.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "log_configuration.0.log_types.*", "AS_RUN"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccChannelConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "log_configuration.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckChannelDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*mediatailor.MediaTailor)
+	conn := testAccProvider.Meta().(*providerMeta).client
 
 	for _, rs := range s.RootModule().Resources {
 		var resourceName string
@@ -328,7 +486,7 @@ func testAccCheckChannelDestroy(s *terraform.State) error {
 		input := &mediatailor.DescribeChannelInput{ChannelName: aws.String(resourceName)}
 		_, err := conn.DescribeChannel(input)
 
-		if strings.Contains(err.Error(), "NotFound") {
+		if isNotFound(err) {
 			continue
 		}
 
@@ -354,6 +512,24 @@ resource "awsmt_channel" "test" {
 `, rName)
 }
 
+func testAccChannelConfig_LogConfiguration(rName string) string {
+	return fmt.Sprintf(`
+resource "awsmt_channel" "test" {
+  name = "%[1]s"
+  log_configuration {
+    log_types = ["AS_RUN"]
+  }
+  outputs {
+    manifest_name                = "default"
+    source_group                 = "default"
+    hls_manifest_windows_seconds = 30
+  }
+  playback_mode = "LOOP"
+  tier = "BASIC"
+}
+`, rName)
+}
+
 func testAccChannelConfig_stopAndDelete(rName string, status string) string {
 	return fmt.Sprintf(`
 resource "awsmt_channel" "test" {
@@ -370,6 +546,23 @@ resource "awsmt_channel" "test" {
 `, rName, status)
 }
 
+func testAccChannelConfig_AllowStopForUpdate(rName string, windowSeconds int, allowStop bool) string {
+	return fmt.Sprintf(`
+resource "awsmt_channel" "test" {
+  name                  = "%[1]s"
+  allow_stop_for_update = %[3]t
+  channel_state         = "RUNNING"
+  outputs {
+    manifest_name                = "default"
+    source_group                 = "default"
+    hls_manifest_windows_seconds = %[2]d
+  }
+  playback_mode = "LOOP"
+  tier = "BASIC"
+}
+`, rName, windowSeconds, allowStop)
+}
+
 func testAccChannelConfig_Conflict(rName string) string {
 	return fmt.Sprintf(`
 resource "awsmt_channel" "test" {
@@ -434,6 +627,57 @@ resource "awsmt_channel" "test" {
 `, rName, num)
 }
 
+func testAccChannelConfig_DefaultTags(rName, defaultValue, k, v string) string {
+	return fmt.Sprintf(`
+provider "awsmt" {
+  default_tags {
+    tags = {
+      "Environment" = "%[2]s"
+    }
+  }
+}
+
+resource "awsmt_channel" "test" {
+  name = "%[1]s"
+  outputs {
+    manifest_name                = "default"
+    source_group                 = "default"
+    hls_manifest_windows_seconds = 30
+  }
+  playback_mode = "LOOP"
+  tags = {
+    "%[3]s" = "%[4]s"
+  }
+  tier = "BASIC"
+}
+`, rName, defaultValue, k, v)
+}
+
+func testAccChannelConfig_IgnoreTags(rName string) string {
+	return fmt.Sprintf(`
+provider "awsmt" {
+  ignore_tags {
+    keys         = ["external"]
+    key_prefixes = ["billing:"]
+  }
+}
+
+resource "awsmt_channel" "test" {
+  name = "%[1]s"
+  outputs {
+    manifest_name                = "default"
+    source_group                 = "default"
+    hls_manifest_windows_seconds = 30
+  }
+  playback_mode = "LOOP"
+  tags = {
+    "Environment" = "dev"
+  }
+  tier = "BASIC"
+}
+`, rName)
+}
+
 func testAccChannelConfig_Tags(rName, k1, v1, k2, v2 string) string {
 	return fmt.Sprintf(`
 resource "awsmt_channel" "test" {
@@ -0,0 +1,439 @@
+package awsmt
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testChannelResourceData(t *testing.T, config map[string]interface{}) *schema.ResourceData {
+	raw := map[string]interface{}{
+		"name":          "test",
+		"outputs":       []interface{}{map[string]interface{}{"manifest_name": "default", "source_group": "default", "hls_manifest_windows_seconds": 30}},
+		"playback_mode": "LOOP",
+	}
+	for k, v := range config {
+		raw[k] = v
+	}
+	d := schema.TestResourceDataRaw(t, resourceChannel().Schema, raw)
+	d.SetId("arn:aws:mediatailor:eu-central-1:123456789012:channel/test")
+	return d
+}
+
+func TestResourceChannelCreate(t *testing.T) {
+	cases := map[string]struct {
+		config   map[string]interface{}
+		errors   map[string]error
+		expected []string
+	}{
+		"stopped channel": {
+			expected: []string{"CreateChannel", "DescribeChannel"},
+		},
+		"running channel with a policy": {
+			config:   map[string]interface{}{"channel_state": "RUNNING", "policy": `{"Statement":[]}`},
+			expected: []string{"CreateChannel", "StartChannel", "DescribeChannel", "PutChannelPolicy", "DescribeChannel", "GetChannelPolicy"},
+		},
+		"failed creation": {
+			errors:   map[string]error{"CreateChannel": errors.New("error")},
+			expected: []string{"CreateChannel"},
+		},
+	}
+
+	for name, c := range cases {
+		// arrange
+		client := newMockMediaTailor("STOPPED")
+		for k, v := range c.errors {
+			client.errors[k] = v
+		}
+		d := testChannelResourceData(t, c.config)
+
+		// act
+		diags := resourceChannelCreate(context.Background(), d, &providerMeta{client: client})
+
+		// assert
+		if diags.HasError() != (len(c.errors) > 0) {
+			t.Errorf("%s: unexpected diagnostics %v", name, diags)
+		}
+		if !reflect.DeepEqual(client.calls, c.expected) {
+			t.Errorf("%s: expected the calls %v, got %v", name, c.expected, client.calls)
+		}
+	}
+}
+
+func TestResourceChannelRead_defaultTags(t *testing.T) {
+	// arrange
+	client := newMockMediaTailor("STOPPED")
+	client.tags = map[string]*string{"Environment": aws.String("default"), "a": aws.String("b")}
+	meta := &providerMeta{client: client, defaultTags: map[string]interface{}{"Environment": "default"}}
+	d := testChannelResourceData(t, map[string]interface{}{"tags": map[string]interface{}{"a": "b"}})
+
+	// act
+	diags := resourceChannelRead(context.Background(), d, meta)
+
+	// assert
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+	if tags := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(tags, map[string]interface{}{"a": "b"}) {
+		t.Errorf("expected the default tags not to be read into tags, got %v", tags)
+	}
+	if tagsAll := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(tagsAll, map[string]interface{}{"Environment": "default", "a": "b"}) {
+		t.Errorf("expected all the tags in tags_all, got %v", tagsAll)
+	}
+}
+
+func TestResourceChannelRead_policy(t *testing.T) {
+	cases := map[string]struct {
+		statePolicy    string
+		existingPolicy string
+		expected       []string
+	}{
+		"unmanaged policy": {statePolicy: "", existingPolicy: `{"Statement":[]}`, expected: []string{"DescribeChannel"}},
+		"changed policy":   {statePolicy: `{"Statement":[]}`, existingPolicy: `{"Statement":[{}]}`, expected: []string{"DescribeChannel", "GetChannelPolicy"}},
+		"deleted policy":   {statePolicy: `{"Statement":[]}`, existingPolicy: "", expected: []string{"DescribeChannel", "GetChannelPolicy"}},
+	}
+
+	for name, c := range cases {
+		// arrange
+		client := newMockMediaTailor("STOPPED")
+		client.policy = c.existingPolicy
+		d := testChannelResourceData(t, map[string]interface{}{"policy": c.statePolicy})
+
+		// act
+		diags := resourceChannelRead(context.Background(), d, &providerMeta{client: client})
+
+		// assert
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected diagnostics %v", name, diags)
+		}
+		if !reflect.DeepEqual(client.calls, c.expected) {
+			t.Errorf("%s: expected the calls %v, got %v", name, c.expected, client.calls)
+		}
+		expectedPolicy := c.existingPolicy
+		if c.statePolicy == "" {
+			expectedPolicy = ""
+		}
+		if policy := d.Get("policy").(string); policy != expectedPolicy {
+			t.Errorf("%s: expected the policy %q, got %q", name, expectedPolicy, policy)
+		}
+	}
+}
+
+func TestResourceChannelUpdate(t *testing.T) {
+	cases := map[string]struct {
+		state    string
+		config   map[string]interface{}
+		errors   map[string]error
+		expected []string
+	}{
+		"stopped channel": {
+			state:    "STOPPED",
+			expected: []string{"DescribeChannel", "UpdateChannel", "DescribeChannel"},
+		},
+		"running channel": {
+			state:    "RUNNING",
+			expected: []string{"DescribeChannel", "StopChannel", "DescribeChannel", "UpdateChannel", "StartChannel", "DescribeChannel", "DescribeChannel"},
+		},
+		"stopped channel to start": {
+			state:    "STOPPED",
+			config:   map[string]interface{}{"channel_state": "RUNNING"},
+			expected: []string{"DescribeChannel", "UpdateChannel", "StartChannel", "DescribeChannel", "DescribeChannel"},
+		},
+		"running channel to stop": {
+			state:    "RUNNING",
+			config:   map[string]interface{}{"channel_state": "STOPPED"},
+			expected: []string{"DescribeChannel", "StopChannel", "DescribeChannel", "UpdateChannel", "DescribeChannel"},
+		},
+		"failed stop": {
+			state:    "RUNNING",
+			errors:   map[string]error{"StopChannel": errors.New("error")},
+			expected: []string{"DescribeChannel", "StopChannel"},
+		},
+		"failed update": {
+			state:    "RUNNING",
+			errors:   map[string]error{"UpdateChannel": errors.New("error")},
+			expected: []string{"DescribeChannel", "StopChannel", "DescribeChannel", "UpdateChannel"},
+		},
+	}
+
+	for name, c := range cases {
+		// arrange
+		client := newMockMediaTailor(c.state)
+		for k, v := range c.errors {
+			client.errors[k] = v
+		}
+		d := testChannelResourceData(t, c.config)
+
+		// act
+		diags := resourceChannelUpdate(context.Background(), d, &providerMeta{client: client})
+
+		// assert
+		if diags.HasError() != (len(c.errors) > 0) {
+			t.Errorf("%s: unexpected diagnostics %v", name, diags)
+		}
+		if !reflect.DeepEqual(client.calls, c.expected) {
+			t.Errorf("%s: expected the calls %v, got %v", name, c.expected, client.calls)
+		}
+	}
+}
+
+// testChannelPlan returns the prior state of a channel in the given state and the plan of the given configuration.
+func testChannelPlan(t *testing.T, client *mockMediaTailor, config map[string]interface{}) (*terraform.InstanceState, *terraform.InstanceDiff, error) {
+	d := testChannelResourceData(t, nil)
+	if err := d.Set("channel_state", client.channelState); err != nil {
+		t.Fatal(err)
+	}
+	state := d.State()
+	raw := map[string]interface{}{
+		"name":          "test",
+		"outputs":       []interface{}{map[string]interface{}{"manifest_name": "default", "source_group": "default", "hls_manifest_windows_seconds": 30}},
+		"playback_mode": "LOOP",
+	}
+	for k, v := range config {
+		raw[k] = v
+	}
+	diff, err := resourceChannel().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), &providerMeta{client: client})
+	return state, diff, err
+}
+
+func TestResourceChannelUpdate_changes(t *testing.T) {
+	changedOutputs := []interface{}{map[string]interface{}{"manifest_name": "default", "source_group": "default", "hls_manifest_windows_seconds": 60}}
+	cases := map[string]struct {
+		state    string
+		config   map[string]interface{}
+		expected []string
+	}{
+		"tags of a running channel": {
+			state:    "RUNNING",
+			config:   map[string]interface{}{"tags": map[string]interface{}{"Team": "video"}},
+			expected: []string{"DescribeChannel", "TagResource", "DescribeChannel"},
+		},
+		"outputs of a running channel": {
+			state:    "RUNNING",
+			config:   map[string]interface{}{"outputs": changedOutputs, "allow_stop_for_update": true},
+			expected: []string{"DescribeChannel", "StopChannel", "DescribeChannel", "UpdateChannel", "StartChannel", "DescribeChannel", "DescribeChannel"},
+		},
+		"outputs of a stopped channel": {
+			state:    "STOPPED",
+			config:   map[string]interface{}{"outputs": changedOutputs},
+			expected: []string{"DescribeChannel", "UpdateChannel", "DescribeChannel"},
+		},
+		"state of a running channel": {
+			state:    "RUNNING",
+			config:   map[string]interface{}{"channel_state": "STOPPED"},
+			expected: []string{"DescribeChannel", "StopChannel", "DescribeChannel", "DescribeChannel"},
+		},
+	}
+
+	for name, c := range cases {
+		// arrange
+		client := newMockMediaTailor(c.state)
+		state, diff, err := testChannelPlan(t, client, c.config)
+		if err != nil {
+			t.Fatalf("%s: unexpected plan error %v", name, err)
+		}
+
+		// act
+		_, diags := resourceChannel().Apply(context.Background(), state, diff, &providerMeta{client: client})
+
+		// assert
+		if diags.HasError() {
+			t.Errorf("%s: unexpected diagnostics %v", name, diags)
+		}
+		if !reflect.DeepEqual(client.calls, c.expected) {
+			t.Errorf("%s: expected the calls %v, got %v", name, c.expected, client.calls)
+		}
+	}
+}
+
+func TestResourceChannelUpdate_policy(t *testing.T) {
+	cases := map[string]struct {
+		existingPolicy string
+		expectError    bool
+		expected       []string
+	}{
+		"channel without policy": {
+			expected: []string{"DescribeChannel", "GetChannelPolicy", "PutChannelPolicy", "DescribeChannel", "GetChannelPolicy"},
+		},
+		"channel with a policy managed elsewhere": {
+			existingPolicy: `{"Statement":[]}`,
+			expectError:    true,
+			expected:       []string{"DescribeChannel", "GetChannelPolicy"},
+		},
+	}
+
+	for name, c := range cases {
+		// arrange
+		client := newMockMediaTailor("STOPPED")
+		client.policy = c.existingPolicy
+		state, diff, err := testChannelPlan(t, client, map[string]interface{}{"policy": `{"Statement":[{}]}`})
+		if err != nil {
+			t.Fatalf("%s: unexpected plan error %v", name, err)
+		}
+
+		// act
+		_, diags := resourceChannel().Apply(context.Background(), state, diff, &providerMeta{client: client})
+
+		// assert
+		if diags.HasError() != c.expectError {
+			t.Errorf("%s: unexpected diagnostics %v", name, diags)
+		}
+		if !reflect.DeepEqual(client.calls, c.expected) {
+			t.Errorf("%s: expected the calls %v, got %v", name, c.expected, client.calls)
+		}
+	}
+}
+
+func TestImportChannelState(t *testing.T) {
+	// arrange
+	client := newMockMediaTailor("STOPPED")
+	client.policy = `{"Statement":[]}`
+	d := resourceChannel().Data(nil)
+	d.SetId("arn:aws:mediatailor:eu-central-1:123456789012:channel/test")
+
+	// act
+	_, err := importChannelState(context.Background(), d, &providerMeta{client: client, region: "eu-central-1"})
+
+	// assert
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(client.calls) != 0 || d.Get("policy").(string) != "" {
+		t.Errorf("expected the policy not to be imported, got the calls %v and the policy %s", client.calls, d.Get("policy"))
+	}
+	if d.Get("region").(string) != "eu-central-1" {
+		t.Errorf("expected the region of the ARN, got %s", d.Get("region"))
+	}
+}
+
+func TestCheckChannelStopForUpdate(t *testing.T) {
+	changedOutputs := []interface{}{map[string]interface{}{"manifest_name": "default", "source_group": "default", "hls_manifest_windows_seconds": 60}}
+	cases := map[string]struct {
+		state       string
+		config      map[string]interface{}
+		expectError bool
+	}{
+		"running channel":             {state: "RUNNING", config: map[string]interface{}{"outputs": changedOutputs}, expectError: true},
+		"running channel with filler": {state: "RUNNING", config: map[string]interface{}{"filler_slate": []interface{}{map[string]interface{}{"source_location_name": "sl", "vod_source_name": "vs"}}}, expectError: true},
+		"allowed stop":                {state: "RUNNING", config: map[string]interface{}{"outputs": changedOutputs, "allow_stop_for_update": true}, expectError: false},
+		"stopped by the update":       {state: "RUNNING", config: map[string]interface{}{"outputs": changedOutputs, "channel_state": "STOPPED"}, expectError: false},
+		"stopped channel":             {state: "STOPPED", config: map[string]interface{}{"outputs": changedOutputs}, expectError: false},
+		"tags of a running channel":   {state: "RUNNING", config: map[string]interface{}{"tags": map[string]interface{}{"Team": "video"}}, expectError: false},
+	}
+
+	for name, c := range cases {
+		_, _, err := testChannelPlan(t, newMockMediaTailor(c.state), c.config)
+		if (err != nil) != c.expectError {
+			t.Errorf("%s: expected error to be %v, got %v", name, c.expectError, err)
+		}
+	}
+}
+
+func TestResourceChannelDelete(t *testing.T) {
+	policy := map[string]interface{}{"policy": `{"Statement":[]}`}
+	cases := map[string]struct {
+		config      map[string]interface{}
+		errors      map[string]error
+		expected    []string
+		expectError bool
+	}{
+		"deletion": {
+			expected: []string{"StopChannel", "DescribeChannel", "DeleteChannel"},
+		},
+		"deletion with a policy": {
+			config:   policy,
+			expected: []string{"StopChannel", "DescribeChannel", "DeleteChannelPolicy", "DeleteChannel"},
+		},
+		"policy already deleted": {
+			config:   policy,
+			errors:   map[string]error{"DeleteChannelPolicy": awserr.New("NotFoundException", "not found", nil)},
+			expected: []string{"StopChannel", "DescribeChannel", "DeleteChannelPolicy", "DeleteChannel"},
+		},
+		"failed policy deletion": {
+			config:      policy,
+			errors:      map[string]error{"DeleteChannelPolicy": errors.New("error")},
+			expected:    []string{"StopChannel", "DescribeChannel", "DeleteChannelPolicy"},
+			expectError: true,
+		},
+		"failed stop": {
+			errors:      map[string]error{"StopChannel": errors.New("error")},
+			expected:    []string{"StopChannel"},
+			expectError: true,
+		},
+	}
+
+	for name, c := range cases {
+		// arrange
+		client := newMockMediaTailor("RUNNING")
+		for k, v := range c.errors {
+			client.errors[k] = v
+		}
+		d := testChannelResourceData(t, c.config)
+
+		// act
+		diags := resourceChannelDelete(context.Background(), d, &providerMeta{client: client})
+
+		// assert
+		if diags.HasError() != c.expectError {
+			t.Errorf("%s: unexpected diagnostics %v", name, diags)
+		}
+		if !reflect.DeepEqual(client.calls, c.expected) {
+			t.Errorf("%s: expected the calls %v, got %v", name, c.expected, client.calls)
+		}
+	}
+}
+
+func TestWaitForChannelState(t *testing.T) {
+	defer func(interval time.Duration) { channelStatePollInterval = interval }(channelStatePollInterval)
+	channelStatePollInterval = time.Millisecond
+
+	cases := map[string]struct {
+		transitionDelay int
+		timeout         time.Duration
+		expectedCalls   int
+		expectedError   bool
+	}{
+		"immediate transition": {0, time.Minute, 1, false},
+		"delayed transition":   {3, time.Minute, 4, false},
+		"timeout":              {1000000, 50 * time.Millisecond, -1, true},
+	}
+
+	for name, c := range cases {
+		// arrange
+		client := newMockMediaTailor("RUNNING")
+		client.transitionDelay = c.transitionDelay
+		client.setChannelState("STOPPED")
+
+		// act
+		err := waitForChannelState(context.Background(), client, "test", "STOPPED", c.timeout)
+
+		// assert
+		if (err != nil) != c.expectedError {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+		if c.expectedCalls >= 0 && len(client.calls) != c.expectedCalls {
+			t.Errorf("%s: expected %d calls, got %v", name, c.expectedCalls, client.calls)
+		}
+	}
+}
+
+func TestWaitForChannelStateError(t *testing.T) {
+	// arrange
+	client := newMockMediaTailor("RUNNING")
+	client.errors["DescribeChannel"] = errors.New("error")
+
+	// act
+	err := waitForChannelState(context.Background(), client, "test", "STOPPED", time.Minute)
+
+	// assert
+	if err == nil || !strings.Contains(err.Error(), "error while waiting for the channel to be stopped") {
+		t.Errorf("expected a wait error, got %v", err)
+	}
+}
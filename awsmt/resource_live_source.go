@@ -6,11 +6,13 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"strings"
+	"time"
 )
 
 func resourceLiveSource() *schema.Resource {
@@ -33,24 +35,34 @@ func resourceLiveSource() *schema.Resource {
 			}),
 			"last_modified_time":   &computedString,
 			"name":                 &requiredString,
+			"region":               &optionalRegion,
 			"source_location_name": &requiredString,
 			"tags":                 &optionalTags,
+			"tags_all":             &computedTags,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateWithRegion,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 		CustomizeDiff: customdiff.Sequence(
 			customdiff.ForceNewIfChange("name", func(ctx context.Context, old, new, meta interface{}) bool { return old.(string) != new.(string) }),
 			customdiff.ForceNewIfChange("source_location_name", func(ctx context.Context, old, new, meta interface{}) bool { return old.(string) != new.(string) }),
+			setTagsDiff,
 		),
 	}
 }
 
 func resourceLiveSourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
+	client := getClient(d, meta)
 
 	params := getCreateLiveSourceInput(d)
-	liveSource, err := client.CreateLiveSource(&params)
+	params.Tags = getTagsAll(d, meta)
+	liveSource, err := client.CreateLiveSourceWithContext(ctx, &params)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while creating the live source: %v", err))
 	}
@@ -59,8 +71,11 @@ func resourceLiveSourceCreate(ctx context.Context, d *schema.ResourceData, meta
 	return resourceLiveSourceRead(ctx, d, meta)
 }
 
-func resourceLiveSourceRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
+func resourceLiveSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	if err := setRegion(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
 	liveSourceName := d.Get("name").(string)
 	sourceLocationName := d.Get("source_location_name").(string)
 
@@ -76,38 +91,44 @@ func resourceLiveSourceRead(_ context.Context, d *schema.ResourceData, meta inte
 
 	input := &mediatailor.DescribeLiveSourceInput{SourceLocationName: &(sourceLocationName), LiveSourceName: aws.String(liveSourceName)}
 
-	res, err := client.DescribeLiveSource(input)
+	res, err := client.DescribeLiveSourceWithContext(ctx, input)
+	if isNotFound(err) && !d.IsNewResource() {
+		tflog.Warn(ctx, "The live source was not found, removing it from the state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while reading the live source: %v", err))
 	}
 
-	if err = setLiveSource(res, d); err != nil {
-		return diag.FromErr(err)
+	if diags := setLiveSource(res, d); diags.HasError() {
+		return diags
+	}
+	if diags := setTagsAndTagsAll(d, meta, res.Tags); diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
 
 func resourceLiveSourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
-
-	if d.HasChange("tags") {
-		oldValue, newValue := d.GetChange("tags")
+	client := getClient(d, meta)
 
+	if d.HasChanges("tags", "tags_all") {
 		resourceName := d.Get("name").(string)
 		sourceLocationName := d.Get("source_location_name").(string)
-		res, err := client.DescribeLiveSource(&mediatailor.DescribeLiveSourceInput{SourceLocationName: &sourceLocationName, LiveSourceName: &resourceName})
+		res, err := client.DescribeLiveSourceWithContext(ctx, &mediatailor.DescribeLiveSourceInput{SourceLocationName: &sourceLocationName, LiveSourceName: &resourceName})
 		if err != nil {
 			return diag.FromErr(err)
 		}
 
-		if err := updateTags(client, res.Arn, oldValue, newValue); err != nil {
+		if err := updateTagsAll(ctx, client, res.Arn, d, meta); err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
 	var params = getUpdateLiveSourceInput(d)
-	liveSource, err := client.UpdateLiveSource(&params)
+	liveSource, err := client.UpdateLiveSourceWithContext(ctx, &params)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while updating the live source: %v", err))
 	}
@@ -116,10 +137,10 @@ func resourceLiveSourceUpdate(ctx context.Context, d *schema.ResourceData, meta
 	return resourceLiveSourceRead(ctx, d, meta)
 }
 
-func resourceLiveSourceDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
+func resourceLiveSourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
 
-	_, err := client.DeleteLiveSource(&mediatailor.DeleteLiveSourceInput{LiveSourceName: aws.String(d.Get("name").(string)), SourceLocationName: aws.String(d.Get("source_location_name").(string))})
+	_, err := client.DeleteLiveSourceWithContext(ctx, &mediatailor.DeleteLiveSourceInput{LiveSourceName: aws.String(d.Get("name").(string)), SourceLocationName: aws.String(d.Get("source_location_name").(string))})
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while deleting the resource: %v", err))
 	}
@@ -24,13 +24,13 @@ func init() {
 			for k, v := range names {
 				_, err = conn.DeleteLiveSource(&mediatailor.DeleteLiveSourceInput{SourceLocationName: &k, LiveSourceName: &v})
 				if err != nil {
-					if !strings.Contains(err.Error(), "NotFound") {
+					if !isNotFound(err) {
 						return err
 					}
 				}
 				_, err = conn.DeleteSourceLocation(&mediatailor.DeleteSourceLocationInput{SourceLocationName: &k})
 				if err != nil {
-					if !strings.Contains(err.Error(), "NotFound") {
+					if !isNotFound(err) {
 						return err
 					}
 				}
@@ -134,7 +134,7 @@ func TestAccLiveSourceResource_tags(t *testing.T) {
 }
 
 func testAccCheckLiveSourceDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*mediatailor.MediaTailor)
+	conn := testAccProvider.Meta().(*providerMeta).client
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "awsmt_live_source" {
@@ -157,7 +157,7 @@ func testAccCheckLiveSourceDestroy(s *terraform.State) error {
 		input := &mediatailor.DescribeLiveSourceInput{LiveSourceName: aws.String(resourceName), SourceLocationName: aws.String("source-location-testacc")}
 		_, err := conn.DescribeLiveSource(input)
 
-		if err != nil && strings.Contains(err.Error(), "NotFound") {
+		if err != nil && isNotFound(err) {
 			continue
 		}
 
@@ -2,8 +2,10 @@ package awsmt
 
 import (
 	"context"
+	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -67,9 +69,11 @@ func resourcePlaybackConfiguration() *schema.Resource {
 			"personalization_threshold_seconds":      &optionalInt,
 			"playback_configuration_arn":             &computedString,
 			"playback_endpoint_prefix":               &computedString,
+			"region":                                 &optionalRegion,
 			"session_initialization_endpoint_prefix": &computedString,
 			"slate_ad_url":                           &optionalString,
 			"tags":                                   &optionalTags,
+			"tags_all":                               &computedTags,
 			"transcode_profile_name":                 &optionalString,
 			"video_content_source_url":               &requiredString,
 			"last_updated": {
@@ -79,59 +83,67 @@ func resourcePlaybackConfiguration() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateWithRegion,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 		CustomizeDiff: customdiff.Sequence(
 			customdiff.ForceNewIfChange("name", func(ctx context.Context, old, new, meta interface{}) bool { return old.(string) != new.(string) }),
+			setTagsDiff,
 		),
 	}
 }
 
 func resourcePlaybackConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mediatailor.MediaTailor)
-	var diags diag.Diagnostics
+	client := getClient(d, m)
 
 	input := getPlaybackConfigurationInput(d)
+	input.Tags = getTagsAll(d, m)
 
-	_, err := client.PutPlaybackConfiguration(&input)
+	_, err := client.PutPlaybackConfigurationWithContext(ctx, &input)
 	if err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(fmt.Errorf("error while creating the playback configuration: %v", err))
 	}
-	resourcePlaybackConfigurationRead(ctx, d, m)
-	d.SetId(*input.Name)
-	return diags
+	d.SetId(aws.StringValue(input.Name))
+
+	return resourcePlaybackConfigurationRead(ctx, d, m)
 }
 
 func resourcePlaybackConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	client := m.(*mediatailor.MediaTailor)
+	client := getClient(d, m)
 
 	// @ADR
 	// Context: Updating tags using the PutPlaybackConfiguration method does not allow to remove them.
 	// Decision: We decided to check for removed tags and remove them using the UntagResource method, while we still use
 	// the PutPlaybackConfiguration method to add and update tags. We use this approach for every resource in the provider.
 	// Consequences: The Update function logic is now more complicated, but tag removal is supported.
-	if d.HasChange("tags") {
-		oldValue, newValue := d.GetChange("tags")
+	if d.HasChanges("tags", "tags_all") {
+		oldValue, _ := d.GetChange("tags_all")
+		newValue := mergeTags(m, d.Get("tags").(map[string]interface{}))
 		var removedTags []string
-		for k := range oldValue.(map[string]interface{}) {
-			if _, ok := (newValue.(map[string]interface{}))[k]; !ok {
+		for k := range removeIgnoredTags(m, oldValue.(map[string]interface{})) {
+			if _, ok := newValue[k]; !ok {
 				removedTags = append(removedTags, k)
 			}
 		}
 		resourceName := d.Get("name").(string)
-		res, err := client.GetPlaybackConfiguration(&mediatailor.GetPlaybackConfigurationInput{Name: &resourceName})
+		res, err := client.GetPlaybackConfigurationWithContext(ctx, &mediatailor.GetPlaybackConfigurationInput{Name: &resourceName})
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		err = deleteTags(client, aws.StringValue(res.PlaybackConfigurationArn), removedTags)
+		err = deleteTags(ctx, client, aws.StringValue(res.PlaybackConfigurationArn), removedTags)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
 	input := getPlaybackConfigurationInput(d)
-	_, err := client.PutPlaybackConfiguration(&input)
+	input.Tags = getTagsAll(d, m)
+	_, err := client.PutPlaybackConfigurationWithContext(ctx, &input)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -139,32 +151,40 @@ func resourcePlaybackConfigurationUpdate(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
-	resourcePlaybackConfigurationRead(ctx, d, m)
-	return diags
+	return resourcePlaybackConfigurationRead(ctx, d, m)
 }
 
-func resourcePlaybackConfigurationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mediatailor.MediaTailor)
-	var diags diag.Diagnostics
-
+func resourcePlaybackConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := getClient(d, m)
+	if err := setRegion(d, m); err != nil {
+		return diag.FromErr(err)
+	}
 	name := d.Get("name").(string)
 	if len(name) == 0 && len(d.Id()) > 0 {
 		name = d.Id()
 	}
-	res, err := client.GetPlaybackConfiguration(&mediatailor.GetPlaybackConfigurationInput{Name: &name})
+	res, err := client.GetPlaybackConfigurationWithContext(ctx, &mediatailor.GetPlaybackConfigurationInput{Name: &name})
+	if isNotFound(err) && !d.IsNewResource() {
+		tflog.Warn(ctx, "The playback configuration was not found, removing it from the state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	output := flattenPlaybackConfiguration((*mediatailor.PlaybackConfiguration)(res))
-	returnPlaybackConfiguration(d, output, diags)
-	return diags
+	if diags := returnPlaybackConfiguration(d, output); diags.HasError() {
+		return diags
+	}
+	if diags := setTagsAndTagsAll(d, m, res.Tags); diags.HasError() {
+		return diags
+	}
+	return nil
 }
 
-func resourcePlaybackConfigurationDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mediatailor.MediaTailor)
-	var diags diag.Diagnostics
-	deletePlaybackConfiguration(client, d.Get("name").(string))
-	d.SetId("")
-	return diags
+func resourcePlaybackConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := getClient(d, m)
+
+	return deletePlaybackConfiguration(ctx, client, d.Get("name").(string))
 }
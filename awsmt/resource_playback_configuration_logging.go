@@ -0,0 +1,115 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/aws/aws-sdk-go/service/mediatailor/mediatailoriface"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
+)
+
+func resourcePlaybackConfigurationLogging() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePlaybackConfigurationLoggingPut,
+		ReadContext:   resourcePlaybackConfigurationLoggingRead,
+		UpdateContext: resourcePlaybackConfigurationLoggingPut,
+		DeleteContext: resourcePlaybackConfigurationLoggingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateWithRegion,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		// @ADR
+		// Context: The log configuration of a playback configuration can only be changed through the
+		// ConfigureLogsForPlaybackConfiguration method, not through PutPlaybackConfiguration.
+		// Decision: We decided to offer a standalone logging resource, identified by the playback configuration name, and
+		// to keep the log_configuration of the playback configuration resource computed.
+		// Consequences: Deleting the logging resource does not delete anything, it disables session logging by setting
+		// percent_enabled to 0.
+		Schema: map[string]*schema.Schema{
+			"percent_enabled": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(0, 100),
+			},
+			"playback_configuration_name": &requiredString,
+			"region":                      &optionalRegion,
+		},
+		CustomizeDiff: customdiff.Sequence(
+			customdiff.ForceNewIfChange("playback_configuration_name", func(ctx context.Context, old, new, meta interface{}) bool { return old.(string) != new.(string) }),
+		),
+	}
+}
+
+func resourcePlaybackConfigurationLoggingPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	name := d.Get("playback_configuration_name").(string)
+
+	if err := configureLogsForPlaybackConfiguration(ctx, client, name, int64(d.Get("percent_enabled").(int))); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(name)
+
+	return resourcePlaybackConfigurationLoggingRead(ctx, d, meta)
+}
+
+func resourcePlaybackConfigurationLoggingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	if err := setRegion(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := client.GetPlaybackConfigurationWithContext(ctx, &mediatailor.GetPlaybackConfigurationInput{Name: aws.String(d.Id())})
+	if isNotFound(err) && !d.IsNewResource() {
+		tflog.Warn(ctx, "The playback configuration was not found, removing it from the state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while reading the playback configuration: %v", err))
+	}
+
+	var percentEnabled int64
+	if res.LogConfiguration != nil {
+		percentEnabled = aws.Int64Value(res.LogConfiguration.PercentEnabled)
+	}
+	if err := d.Set("percent_enabled", percentEnabled); err != nil {
+		return diag.FromErr(fmt.Errorf("error while setting the percent enabled: %v", err))
+	}
+	if err := d.Set("playback_configuration_name", res.Name); err != nil {
+		return diag.FromErr(fmt.Errorf("error while setting the playback configuration name: %v", err))
+	}
+
+	return nil
+}
+
+func resourcePlaybackConfigurationLoggingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+
+	if err := configureLogsForPlaybackConfiguration(ctx, client, d.Id(), 0); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func configureLogsForPlaybackConfiguration(ctx context.Context, client mediatailoriface.MediaTailorAPI, name string, percentEnabled int64) error {
+	_, err := client.ConfigureLogsForPlaybackConfigurationWithContext(ctx, &mediatailor.ConfigureLogsForPlaybackConfigurationInput{
+		PercentEnabled:            aws.Int64(percentEnabled),
+		PlaybackConfigurationName: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("error while configuring the logs for the playback configuration: %v", err)
+	}
+	return nil
+}
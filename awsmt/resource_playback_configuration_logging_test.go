@@ -0,0 +1,104 @@
+package awsmt
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func init() {
+	resource.AddTestSweepers("test_playback_configuration_logging", &resource.Sweeper{
+		Name: "test_playback_configuration_logging",
+		F: func(region string) error {
+			client, err := sharedClientForRegion(region)
+			if err != nil {
+				return fmt.Errorf("error getting client: %s", err)
+			}
+			conn := client.(*mediatailor.MediaTailor)
+			name := "playback_configuration_logging_test"
+			_, err = conn.DeletePlaybackConfiguration(&mediatailor.DeletePlaybackConfigurationInput{Name: &name})
+			if err != nil {
+				if !isNotFound(err) {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccPlaybackConfigurationLoggingResource_basic(t *testing.T) {
+	rName := "playback_configuration_logging_test"
+	resourceName := "awsmt_playback_configuration_logging.test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckPlaybackConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPlaybackConfigurationLoggingConfig(rName, 25),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", rName),
+					resource.TestCheckResourceAttr(resourceName, "playback_configuration_name", rName),
+					resource.TestCheckResourceAttr(resourceName, "percent_enabled", "25"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccPlaybackConfigurationLoggingConfig(rName, 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "percent_enabled", "100"),
+				),
+			},
+			{
+				Config: testAccPlaybackConfigurationLoggingConfig(rName, 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awsmt_playback_configuration.test", "log_configuration.0.percent_enabled", "100"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPlaybackConfigurationLoggingResource_validatePercentEnabled(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "awsmt_playback_configuration_logging" "test" {
+  playback_configuration_name = "playback_configuration_logging_validation"
+  percent_enabled             = 101
+}
+`,
+				ExpectError: regexp.MustCompile(`expected percent_enabled to be in the range \(0 - 100\)`),
+			},
+		},
+	})
+}
+
+func testAccPlaybackConfigurationLoggingConfig(name string, percentEnabled int) string {
+	return fmt.Sprintf(`
+resource "awsmt_playback_configuration" "test" {
+  ad_decision_server_url = "https://exampleurl.com/"
+  name                   = "%[1]s"
+  dash_configuration {
+    mpd_location         = "EMT_DEFAULT"
+    origin_manifest_type = "MULTI_PERIOD"
+  }
+  video_content_source_url = "https://exampleurl.com"
+}
+
+resource "awsmt_playback_configuration_logging" "test" {
+  playback_configuration_name = awsmt_playback_configuration.test.name
+  percent_enabled             = %[2]d
+}
+`, name, percentEnabled)
+}
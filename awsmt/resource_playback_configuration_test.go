@@ -86,6 +86,59 @@ func TestAccPlaybackConfigurationResourceImport(t *testing.T) {
 	})
 }
 
+func TestAccPlaybackConfigurationResourceRegion(t *testing.T) {
+	resourceName := "awsmt_playback_configuration.region_test"
+	rName := "tf_test_acc_region"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckPlaybackConfigurationRegionDestroy(rName, "us-east-1"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPlaybackConfigurationResourceRegion(rName, "us-east-1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "region", "us-east-1"),
+					resource.TestMatchResourceAttr(resourceName, "playback_configuration_arn", regexp.MustCompile(`^arn:aws:mediatailor:us-east-1:`)),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     rName + "@us-east-1",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPlaybackConfigurationResourceDisappears(t *testing.T) {
+	resourceName := "awsmt_playback_configuration.region_test"
+	rName := "tf_test_acc_disappears"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckPlaybackConfigurationRegionDestroy(rName, "eu-central-1"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPlaybackConfigurationResourceRegion(rName, "eu-central-1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					testAccDeletePlaybackConfiguration(rName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccDeletePlaybackConfiguration(name string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		c := testAccProvider.Meta().(*providerMeta).client
+		_, err := c.DeletePlaybackConfiguration(&mediatailor.DeletePlaybackConfigurationInput{Name: &name})
+		return err
+	}
+}
+
 func TestAccPlaybackConfigurationResourceTaint(t *testing.T) {
 	resourceName := "awsmt_playback_configuration.taint_test"
 	firstEndpoint := ""
@@ -200,7 +253,7 @@ func importPreCheck(t *testing.T, region string) {
 }
 
 func testAccCheckPlaybackConfigurationDestroy(_ *terraform.State) error {
-	c := testAccProvider.Meta().(*mediatailor.MediaTailor)
+	c := testAccProvider.Meta().(*providerMeta).client
 	name := "test_playback_configuration_awsmt"
 	_, err := c.DeletePlaybackConfiguration(&mediatailor.DeletePlaybackConfigurationInput{Name: &name})
 	if err != nil {
@@ -209,6 +262,35 @@ func testAccCheckPlaybackConfigurationDestroy(_ *terraform.State) error {
 	return nil
 }
 
+func testAccCheckPlaybackConfigurationRegionDestroy(name, region string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		c := testAccProvider.Meta().(*providerMeta).clientForRegion(region)
+		_, err := c.GetPlaybackConfiguration(&mediatailor.GetPlaybackConfigurationInput{Name: &name})
+		if err == nil {
+			return fmt.Errorf("playback configuration %s still exists in %s", name, region)
+		}
+		if !isNotFound(err) {
+			return err
+		}
+		return nil
+	}
+}
+
+func testAccPlaybackConfigurationResourceRegion(name, region string) string {
+	return fmt.Sprintf(`
+resource "awsmt_playback_configuration" "region_test" {
+  ad_decision_server_url = "https://exampleurl.com/"
+  name                   = "%[1]s"
+  region                 = "%[2]s"
+  dash_configuration {
+    mpd_location         = "EMT_DEFAULT"
+    origin_manifest_type = "MULTI_PERIOD"
+  }
+  video_content_source_url = "https://exampleurl.com"
+}
+`, name, region)
+}
+
 func testAccPlaybackConfigurationResourceTags() string {
 	return `
 resource "awsmt_playback_configuration" "tags_test"{
@@ -1,7 +1,10 @@
 package awsmt
 
 import (
+	"context"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
 	"testing"
@@ -11,9 +14,20 @@ var sess, _ = session.NewSession(&aws.Config{Region: aws.String("eu-central-11")
 var c = mediatailor.New(sess)
 
 func TestGetPlaybackConfigurationError(t *testing.T) {
-	v, err := getSinglePlaybackConfiguration(c, "not-a-configuration")
+	v, err := getSinglePlaybackConfiguration(context.Background(), c, "not-a-configuration")
 
 	if err == nil {
 		t.Fatalf("expected error, got: %v", v)
 	}
 }
+
+func TestGetPlaybackConfigurationCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := getSinglePlaybackConfiguration(ctx, c, "not-a-configuration")
+
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != request.CanceledErrorCode {
+		t.Fatalf("expected the request to be canceled, got: %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"strings"
+	"time"
+)
+
+func resourcePrefetchSchedule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePrefetchScheduleCreate,
+		ReadContext:   resourcePrefetchScheduleRead,
+		DeleteContext: resourcePrefetchScheduleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateWithRegion,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		// @ADR
+		// Context: MediaTailor does not offer an API to update prefetch schedules.
+		// Decision: We decided to mark every argument as ForceNew and not to implement the Update function.
+		// Consequences: Any change to a prefetch schedule deletes it and creates a new one with the same name. The
+		// nested arguments are also ForceNew, because the SDK does not propagate ForceNew from their parent lists.
+		Schema: map[string]*schema.Schema{
+			"arn": &computedString,
+			"consumption": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"avail_matching_criteria": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"dynamic_variable": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+									"operator": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validation.StringInSlice([]string{"EQUALS"}, false),
+									},
+								},
+							},
+						},
+						"end_time":   &requiredForceNewTimestamp,
+						"start_time": &optionalForceNewTimestamp,
+					},
+				},
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"playback_configuration_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"region": &optionalRegion,
+			"retrieval": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dynamic_variables": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"end_time":   &requiredForceNewTimestamp,
+						"start_time": &optionalForceNewTimestamp,
+					},
+				},
+			},
+			"stream_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourcePrefetchScheduleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+
+	params, err := getCreatePrefetchScheduleInput(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	prefetchSchedule, err := client.CreatePrefetchScheduleWithContext(ctx, &params)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while creating the prefetch schedule: %v", err))
+	}
+	d.SetId(fmt.Sprintf("%s/%s", aws.StringValue(prefetchSchedule.PlaybackConfigurationName), aws.StringValue(prefetchSchedule.Name)))
+
+	return resourcePrefetchScheduleRead(ctx, d, meta)
+}
+
+func resourcePrefetchScheduleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	if err := setRegion(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+	name := d.Get("name").(string)
+	playbackConfigurationName := d.Get("playback_configuration_name").(string)
+
+	if len(name) == 0 && len(d.Id()) > 0 {
+		idSections := strings.Split(d.Id(), "/")
+		if len(idSections) != 2 || idSections[0] == "" || idSections[1] == "" {
+			return diag.Errorf("unexpected format of the ID (%s), expected playback_configuration_name/schedule_name", d.Id())
+		}
+		playbackConfigurationName = idSections[0]
+		name = idSections[1]
+	}
+
+	res, err := client.GetPrefetchScheduleWithContext(ctx, &mediatailor.GetPrefetchScheduleInput{Name: aws.String(name), PlaybackConfigurationName: aws.String(playbackConfigurationName)})
+	if isNotFound(err) && !d.IsNewResource() {
+		tflog.Warn(ctx, "The prefetch schedule was not found, removing it from the state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while reading the prefetch schedule: %v", err))
+	}
+
+	if diags := setPrefetchSchedule(res, d); diags.HasError() {
+		return diags
+	}
+
+	return nil
+}
+
+func resourcePrefetchScheduleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+
+	_, err := client.DeletePrefetchScheduleWithContext(ctx, &mediatailor.DeletePrefetchScheduleInput{Name: aws.String(d.Get("name").(string)), PlaybackConfigurationName: aws.String(d.Get("playback_configuration_name").(string))})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while deleting the resource: %v", err))
+	}
+
+	return nil
+}
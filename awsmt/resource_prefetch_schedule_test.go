@@ -0,0 +1,169 @@
+package awsmt
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func init() {
+	resource.AddTestSweepers("test_prefetch_schedule", &resource.Sweeper{
+		Name: "test_prefetch_schedule",
+		F: func(region string) error {
+			client, err := sharedClientForRegion(region)
+			if err != nil {
+				return fmt.Errorf("error getting client: %s", err)
+			}
+			conn := client.(*mediatailor.MediaTailor)
+			names := map[string]string{"prefetch_schedule_playback_configuration": "prefetch_schedule_test"}
+			for k, v := range names {
+				_, err = conn.DeletePrefetchSchedule(&mediatailor.DeletePrefetchScheduleInput{PlaybackConfigurationName: &k, Name: &v})
+				if err != nil {
+					if !isNotFound(err) {
+						return err
+					}
+				}
+				_, err = conn.DeletePlaybackConfiguration(&mediatailor.DeletePlaybackConfigurationInput{Name: &k})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccPrefetchScheduleResource_basic(t *testing.T) {
+	rName := "prefetch_schedule_test"
+	playbackConfigurationName := "prefetch_schedule_playback_configuration"
+	resourceName := "awsmt_prefetch_schedule.test"
+	startTime := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckPrefetchScheduleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrefetchScheduleConfig(playbackConfigurationName, rName, startTime, "stream_1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("%s/%s", playbackConfigurationName, rName)),
+					resource.TestMatchResourceAttr(resourceName, "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:prefetchSchedule\/.*$`)),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "playback_configuration_name", playbackConfigurationName),
+					resource.TestCheckResourceAttr(resourceName, "consumption.0.avail_matching_criteria.0.dynamic_variable", "scte.event_id"),
+					resource.TestCheckResourceAttr(resourceName, "consumption.0.avail_matching_criteria.0.operator", "EQUALS"),
+					resource.TestCheckResourceAttr(resourceName, "retrieval.0.dynamic_variables.scte.event_id", "1234"),
+					resource.TestCheckResourceAttr(resourceName, "stream_id", "stream_1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccPrefetchScheduleConfig(playbackConfigurationName, rName, startTime, "stream_2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "stream_id", "stream_2"),
+				),
+			},
+			{
+				Config: testAccPrefetchScheduleConfig(playbackConfigurationName, rName, startTime.Add(30*time.Minute), "stream_2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "consumption.0.end_time", startTime.Add(150*time.Minute).Format(time.RFC3339)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPrefetchScheduleResource_validateTimestamp(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckPrefetchScheduleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "awsmt_prefetch_schedule" "test" {
+  name                        = "prefetch_schedule_validation"
+  playback_configuration_name = "prefetch_schedule_validation"
+  consumption {
+    end_time = "tomorrow"
+  }
+  retrieval {
+    end_time = "2030-01-01T00:00:00Z"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`to be a valid RFC3339 date`),
+			},
+		},
+	})
+}
+
+func testAccCheckPrefetchScheduleDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*providerMeta).client
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "awsmt_prefetch_schedule" {
+			continue
+		}
+
+		idSections := strings.Split(rs.Primary.ID, "/")
+		input := &mediatailor.GetPrefetchScheduleInput{PlaybackConfigurationName: aws.String(idSections[0]), Name: aws.String(idSections[1])}
+		_, err := conn.GetPrefetchSchedule(input)
+
+		if err != nil && isNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccPrefetchScheduleConfig(playbackConfigurationName, name string, startTime time.Time, streamId string) string {
+	return fmt.Sprintf(`
+resource "awsmt_playback_configuration" "test" {
+  ad_decision_server_url = "https://exampleurl.com/"
+  name                   = "%[1]s"
+  dash_configuration {
+    mpd_location         = "EMT_DEFAULT"
+    origin_manifest_type = "MULTI_PERIOD"
+  }
+  video_content_source_url = "https://exampleurl.com"
+}
+
+resource "awsmt_prefetch_schedule" "test" {
+  name                        = "%[2]s"
+  playback_configuration_name = awsmt_playback_configuration.test.name
+  consumption {
+    avail_matching_criteria {
+      dynamic_variable = "scte.event_id"
+      operator         = "EQUALS"
+    }
+    start_time = "%[4]s"
+    end_time   = "%[5]s"
+  }
+  retrieval {
+    dynamic_variables = {
+      "scte.event_id" = "1234"
+    }
+    start_time = "%[3]s"
+    end_time   = "%[4]s"
+  }
+  stream_id = "%[6]s"
+}
+`, playbackConfigurationName, name, startTime.Format(time.RFC3339), startTime.Add(time.Hour).Format(time.RFC3339), startTime.Add(2*time.Hour).Format(time.RFC3339), streamId)
+}
@@ -0,0 +1,65 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"testing"
+)
+
+func testPrefetchScheduleConfig(consumption, retrieval map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                        "test",
+		"playback_configuration_name": "test",
+		"consumption":                 []interface{}{consumption},
+		"retrieval":                   []interface{}{retrieval},
+	}
+}
+
+func TestResourcePrefetchScheduleDiff(t *testing.T) {
+	consumption := map[string]interface{}{
+		"avail_matching_criteria": []interface{}{map[string]interface{}{"dynamic_variable": "scte.event_id", "operator": "EQUALS"}},
+		"end_time":                "2030-01-01T02:00:00Z",
+		"start_time":              "2030-01-01T01:00:00Z",
+	}
+	retrieval := map[string]interface{}{
+		"dynamic_variables": map[string]interface{}{"scte.event_id": "1234"},
+		"end_time":          "2030-01-01T01:00:00Z",
+		"start_time":        "2030-01-01T00:00:00Z",
+	}
+	changed := func(values map[string]interface{}, key string, value interface{}) map[string]interface{} {
+		result := map[string]interface{}{}
+		for k, v := range values {
+			result[k] = v
+		}
+		result[key] = value
+		return result
+	}
+	cases := map[string]map[string]interface{}{
+		"consumption end time":        testPrefetchScheduleConfig(changed(consumption, "end_time", "2030-01-01T03:00:00Z"), retrieval),
+		"consumption start time":      testPrefetchScheduleConfig(changed(consumption, "start_time", "2030-01-01T01:30:00Z"), retrieval),
+		"avail matching criteria":     testPrefetchScheduleConfig(changed(consumption, "avail_matching_criteria", []interface{}{map[string]interface{}{"dynamic_variable": "scte.segmentation_event_id", "operator": "EQUALS"}}), retrieval),
+		"retrieval end time":          testPrefetchScheduleConfig(consumption, changed(retrieval, "end_time", "2030-01-01T01:30:00Z")),
+		"retrieval start time":        testPrefetchScheduleConfig(consumption, changed(retrieval, "start_time", "2030-01-01T00:30:00Z")),
+		"retrieval dynamic variables": testPrefetchScheduleConfig(consumption, changed(retrieval, "dynamic_variables", map[string]interface{}{"scte.event_id": "5678"})),
+	}
+
+	// arrange
+	r := resourcePrefetchSchedule()
+	d := schema.TestResourceDataRaw(t, r.Schema, testPrefetchScheduleConfig(consumption, retrieval))
+	d.SetId("test/test")
+	state := d.State()
+
+	for name, config := range cases {
+		// act
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), &providerMeta{})
+
+		// assert
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", name, err)
+		}
+		if diff == nil || !diff.RequiresNew() {
+			t.Errorf("%s: expected the change to require a new resource, got %v", name, diff)
+		}
+	}
+}
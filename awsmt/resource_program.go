@@ -0,0 +1,216 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"math"
+	"strings"
+	"time"
+)
+
+func resourceProgram() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProgramCreate,
+		ReadContext:   resourceProgramRead,
+		UpdateContext: resourceProgramUpdate,
+		DeleteContext: resourceProgramDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateWithRegion,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			// @ADR
+			// Context: Ad breaks carry SCTE-35 messages whose fields are filled with default values by MediaTailor
+			// when they are not specified.
+			// Decision: We decided to model the splice insert and time signal messages as nested blocks with optional
+			// and computed fields, and to check that the message matches the message type when planning.
+			// Consequences: Omitted fields do not cause a diff after MediaTailor filled them, but removing a field from
+			// the configuration keeps the value that was last read.
+			"ad_breaks": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"message_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringInSlice([]string{"SPLICE_INSERT", "TIME_SIGNAL"}, false),
+						},
+						"offset_millis": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"slate": createOptionalList(map[string]*schema.Schema{
+							"source_location_name": &optionalString,
+							"vod_source_name":      &optionalString,
+						}),
+						"splice_insert_message": createOptionalList(map[string]*schema.Schema{
+							"avail_num":         optionalComputedIntBetween(0, 255),
+							"avails_expected":   optionalComputedIntBetween(0, 255),
+							"splice_event_id":   optionalComputedIntBetween(1, math.MaxInt32),
+							"unique_program_id": optionalComputedIntBetween(0, 65535),
+						}),
+						"time_signal_message": createOptionalList(map[string]*schema.Schema{
+							"segmentation_descriptors": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"segment_num":            optionalComputedIntBetween(0, 255),
+										"segmentation_event_id":  optionalComputedIntBetween(1, math.MaxInt32),
+										"segmentation_type_id":   optionalComputedIntBetween(0, 255),
+										"segmentation_upid":      {Type: schema.TypeString, Optional: true, Computed: true},
+										"segmentation_upid_type": optionalComputedIntBetween(0, 255),
+										"segments_expected":      optionalComputedIntBetween(0, 255),
+										"sub_segment_num":        optionalComputedIntBetween(0, 255),
+										"sub_segments_expected":  optionalComputedIntBetween(0, 255),
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			"arn":             &computedString,
+			"channel_name":    &requiredString,
+			"creation_time":   &computedString,
+			"duration_millis": &computedInt,
+			"live_source_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"vod_source_name"},
+			},
+			"name":                 &requiredString,
+			"region":               &optionalRegion,
+			"scheduled_start_time": &computedString,
+			// @ADR
+			// Context: DescribeProgram does not return the transition used to schedule the program, only the resulting
+			// start time and duration.
+			// Decision: We decided to keep the schedule configuration as declared in the state, and to expose the values
+			// computed by MediaTailor through the top level scheduled_start_time and duration_millis attributes.
+			// Consequences: Changes to the schedule made outside of Terraform are not detected. An imported program has
+			// no schedule configuration in the state, so the transition fields only force a new resource when they
+			// change from a value that is already in the state.
+			"schedule_configuration": createRequiredList(map[string]*schema.Schema{
+				"duration_millis": &optionalInt,
+				"relative_position": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"AFTER_PROGRAM", "BEFORE_PROGRAM"}, false),
+				},
+				"relative_program":            &optionalString,
+				"scheduled_start_time_millis": &optionalInt,
+				"transition_type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"ABSOLUTE", "RELATIVE"}, false),
+				},
+			}),
+			"source_location_name": &requiredString,
+			"vod_source_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"live_source_name"},
+			},
+		},
+		CustomizeDiff: customdiff.Sequence(
+			validateAdBreaks,
+			validateTransition,
+			customdiff.ForceNewIfChange("name", func(ctx context.Context, old, new, meta interface{}) bool { return old.(string) != new.(string) }),
+			customdiff.ForceNewIfChange("channel_name", func(ctx context.Context, old, new, meta interface{}) bool { return old.(string) != new.(string) }),
+			customdiff.ForceNewIfChange("source_location_name", func(ctx context.Context, old, new, meta interface{}) bool { return old.(string) != new.(string) }),
+			customdiff.ForceNewIfChange("vod_source_name", func(ctx context.Context, old, new, meta interface{}) bool { return old.(string) != new.(string) }),
+			customdiff.ForceNewIfChange("live_source_name", func(ctx context.Context, old, new, meta interface{}) bool { return old.(string) != new.(string) }),
+			customdiff.ForceNewIfChange("schedule_configuration.0.transition_type", transitionChanged),
+			customdiff.ForceNewIfChange("schedule_configuration.0.relative_position", transitionChanged),
+			customdiff.ForceNewIfChange("schedule_configuration.0.relative_program", transitionChanged),
+		),
+	}
+}
+
+func resourceProgramCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+
+	params := getCreateProgramInput(d)
+	program, err := client.CreateProgramWithContext(ctx, &params)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while creating the program: %v", err))
+	}
+	d.SetId(aws.StringValue(program.Arn))
+
+	return resourceProgramRead(ctx, d, meta)
+}
+
+func resourceProgramRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	if err := setRegion(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+	programName := d.Get("name").(string)
+	channelName := d.Get("channel_name").(string)
+
+	if len(programName) == 0 && len(d.Id()) > 0 {
+		resourceArn, err := arn.Parse(d.Id())
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error parsing the name from resource arn: %v", err))
+		}
+		arnSections := strings.Split(resourceArn.Resource, "/")
+		programName = arnSections[len(arnSections)-1]
+		channelName = arnSections[len(arnSections)-2]
+	}
+
+	res, err := client.DescribeProgramWithContext(ctx, &mediatailor.DescribeProgramInput{ChannelName: aws.String(channelName), ProgramName: aws.String(programName)})
+	if isNotFound(err) && !d.IsNewResource() {
+		tflog.Warn(ctx, "The program was not found, removing it from the state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while reading the program: %v", err))
+	}
+
+	if diags := setProgram(res, d); diags.HasError() {
+		return diags
+	}
+
+	return nil
+}
+
+func resourceProgramUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+
+	params := getUpdateProgramInput(d)
+	program, err := client.UpdateProgramWithContext(ctx, &params)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while updating the program: %v", err))
+	}
+	d.SetId(aws.StringValue(program.Arn))
+
+	return resourceProgramRead(ctx, d, meta)
+}
+
+func resourceProgramDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+
+	_, err := client.DeleteProgramWithContext(ctx, &mediatailor.DeleteProgramInput{ChannelName: aws.String(d.Get("channel_name").(string)), ProgramName: aws.String(d.Get("name").(string))})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error while deleting the resource: %v", err))
+	}
+
+	return nil
+}
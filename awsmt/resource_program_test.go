@@ -0,0 +1,367 @@
+package awsmt
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func init() {
+	resource.AddTestSweepers("test_program", &resource.Sweeper{
+		Name: "test_program",
+		F: func(region string) error {
+			client, err := sharedClientForRegion(region)
+			if err != nil {
+				return fmt.Errorf("error getting client: %s", err)
+			}
+			conn := client.(*mediatailor.MediaTailor)
+			names := map[string]string{"program_test_channel": "program_test_basic", "program_update_channel": "program_test_update", "program_time_signal_channel": "program_test_time_signal"}
+			for k, v := range names {
+				_, err = conn.DeleteProgram(&mediatailor.DeleteProgramInput{ChannelName: &k, ProgramName: &v})
+				if err != nil {
+					if !isNotFound(err) {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccProgramResource_basic(t *testing.T) {
+	rName := "program_test_basic"
+	channelName := "program_test_channel"
+	sourceLocationName := "program_test_source_location"
+	vodSourceName := "program_test_vod_source"
+	startTime := time.Now().Add(time.Hour).UnixMilli()
+	resourceName := "awsmt_program.test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckProgramDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProgramConfig(sourceLocationName, vodSourceName, channelName, rName, 10000, startTime),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceName, "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:program\/.*$`)),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "channel_name", channelName),
+					resource.TestCheckResourceAttr(resourceName, "source_location_name", sourceLocationName),
+					resource.TestCheckResourceAttr(resourceName, "vod_source_name", vodSourceName),
+					resource.TestCheckResourceAttr(resourceName, "ad_breaks.0.offset_millis", "10000"),
+					resource.TestCheckResourceAttr(resourceName, "ad_breaks.0.slate.0.vod_source_name", vodSourceName),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"schedule_configuration"},
+			},
+		},
+	})
+}
+
+func TestAccProgramResource_update(t *testing.T) {
+	rName := "program_test_update"
+	channelName := "program_update_channel"
+	sourceLocationName := "program_update_source_location"
+	vodSourceName := "program_update_vod_source"
+	startTime := time.Now().Add(time.Hour).UnixMilli()
+	resourceName := "awsmt_program.test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckProgramDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProgramConfig(sourceLocationName, vodSourceName, channelName, rName, 10000, startTime),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "ad_breaks.0.offset_millis", "10000"),
+				),
+			},
+			{
+				Config: testAccProgramConfig(sourceLocationName, vodSourceName, channelName, rName, 20000, startTime),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "ad_breaks.0.offset_millis", "20000"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProgramResource_validateTransitionType(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckProgramDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProgramConfig_TransitionType("TEST"),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta("transition_type to be one of [ABSOLUTE RELATIVE]")),
+			},
+			{
+				Config:      testAccProgramConfig_TransitionType("RELATIVE"),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta("relative_program is required with the RELATIVE transition type")),
+			},
+			{
+				Config:      testAccProgramConfig_TransitionType("ABSOLUTE"),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta("scheduled_start_time_millis is required with the ABSOLUTE transition type")),
+			},
+		},
+	})
+}
+
+func TestAccProgramResource_timeSignal(t *testing.T) {
+	rName := "program_test_time_signal"
+	channelName := "program_time_signal_channel"
+	sourceLocationName := "program_time_signal_source_location"
+	vodSourceName := "program_time_signal_vod_source"
+	startTime := time.Now().Add(time.Hour).UnixMilli()
+	resourceName := "awsmt_program.test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckProgramDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProgramConfig_TimeSignal(sourceLocationName, vodSourceName, channelName, rName, startTime),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "ad_breaks.0.message_type", "SPLICE_INSERT"),
+					resource.TestCheckResourceAttr(resourceName, "ad_breaks.0.splice_insert_message.0.avail_num", "1"),
+					resource.TestCheckResourceAttr(resourceName, "ad_breaks.0.splice_insert_message.0.avails_expected", "2"),
+					resource.TestCheckResourceAttr(resourceName, "ad_breaks.0.splice_insert_message.0.splice_event_id", "3"),
+					resource.TestCheckResourceAttr(resourceName, "ad_breaks.0.splice_insert_message.0.unique_program_id", "4"),
+					resource.TestCheckResourceAttr(resourceName, "ad_breaks.1.message_type", "TIME_SIGNAL"),
+					resource.TestCheckResourceAttr(resourceName, "ad_breaks.1.time_signal_message.0.segmentation_descriptors.0.segmentation_type_id", "52"),
+					resource.TestCheckResourceAttr(resourceName, "ad_breaks.1.time_signal_message.0.segmentation_descriptors.0.segmentation_upid", "0123456789abcdef"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"schedule_configuration"},
+			},
+		},
+	})
+}
+
+func TestAccProgramResource_validateAdBreaks(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckProgramDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProgramConfig_MessageType("SPLICE_INSERT"),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta("time_signal_message requires the TIME_SIGNAL message type")),
+			},
+			{
+				Config:      testAccProgramConfig_MessageType("TEST"),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta("message_type to be one of [SPLICE_INSERT TIME_SIGNAL]")),
+			},
+		},
+	})
+}
+
+func testAccCheckProgramDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*providerMeta).client
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "awsmt_program" {
+			continue
+		}
+
+		resourceArn, err := arn.Parse(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error parsing resource arn: %s.\n%s", err, rs.Primary.ID)
+		}
+		arnSections := strings.Split(resourceArn.Resource, "/")
+		programName := arnSections[len(arnSections)-1]
+		channelName := arnSections[len(arnSections)-2]
+
+		input := &mediatailor.DescribeProgramInput{ChannelName: aws.String(channelName), ProgramName: aws.String(programName)}
+		_, err = conn.DescribeProgram(input)
+
+		if err != nil && isNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccProgramConfig(sourceLocationName, vodSourceName, channelName, programName string, offset int, startTime int64) string {
+	return fmt.Sprintf(`
+resource "awsmt_source_location" "example" {
+  name = "%[1]s"
+  http_configuration_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
+}
+
+resource "awsmt_vod_source" "test" {
+  http_package_configurations {
+    path = "/test-img.jpeg"
+    source_group = "default"
+    type = "HLS"
+  }
+  source_location_name = awsmt_source_location.example.name
+  name = "%[2]s"
+}
+
+resource "awsmt_channel" "test" {
+  name = "%[3]s"
+  outputs {
+    manifest_name                = "default"
+    source_group                 = "default"
+    hls_manifest_windows_seconds = 30
+  }
+  filler_slate {
+    source_location_name = awsmt_source_location.example.name
+    vod_source_name      = awsmt_vod_source.test.name
+  }
+  playback_mode = "LINEAR"
+  tier          = "BASIC"
+}
+
+resource "awsmt_program" "test" {
+  channel_name         = awsmt_channel.test.name
+  name                 = "%[4]s"
+  source_location_name = awsmt_source_location.example.name
+  vod_source_name      = awsmt_vod_source.test.name
+  schedule_configuration {
+    transition_type             = "ABSOLUTE"
+    relative_position           = "AFTER_PROGRAM"
+    scheduled_start_time_millis = %[6]d
+  }
+  ad_breaks {
+    message_type  = "SPLICE_INSERT"
+    offset_millis = %[5]d
+    slate {
+      source_location_name = awsmt_source_location.example.name
+      vod_source_name      = awsmt_vod_source.test.name
+    }
+  }
+}
+`, sourceLocationName, vodSourceName, channelName, programName, offset, startTime)
+}
+
+func testAccProgramConfig_TransitionType(transitionType string) string {
+	return fmt.Sprintf(`
+resource "awsmt_program" "test" {
+  channel_name         = "program_validation_channel"
+  name                 = "program_validation"
+  source_location_name = "program_validation_source_location"
+  vod_source_name      = "program_validation_vod_source"
+  schedule_configuration {
+    transition_type   = "%[1]s"
+    relative_position = "AFTER_PROGRAM"
+  }
+}
+`, transitionType)
+}
+
+func testAccProgramConfig_TimeSignal(sourceLocationName, vodSourceName, channelName, programName string, startTime int64) string {
+	return fmt.Sprintf(`
+resource "awsmt_source_location" "example" {
+  name = "%[1]s"
+  http_configuration_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
+}
+
+resource "awsmt_vod_source" "test" {
+  http_package_configurations {
+    path = "/test-img.jpeg"
+    source_group = "default"
+    type = "HLS"
+  }
+  source_location_name = awsmt_source_location.example.name
+  name = "%[2]s"
+}
+
+resource "awsmt_channel" "test" {
+  name = "%[3]s"
+  outputs {
+    manifest_name                = "default"
+    source_group                 = "default"
+    hls_manifest_windows_seconds = 30
+  }
+  filler_slate {
+    source_location_name = awsmt_source_location.example.name
+    vod_source_name      = awsmt_vod_source.test.name
+  }
+  playback_mode = "LINEAR"
+  tier          = "BASIC"
+}
+
+resource "awsmt_program" "test" {
+  channel_name         = awsmt_channel.test.name
+  name                 = "%[4]s"
+  source_location_name = awsmt_source_location.example.name
+  vod_source_name      = awsmt_vod_source.test.name
+  schedule_configuration {
+    transition_type             = "ABSOLUTE"
+    relative_position           = "AFTER_PROGRAM"
+    scheduled_start_time_millis = %[5]d
+  }
+  ad_breaks {
+    message_type  = "SPLICE_INSERT"
+    offset_millis = 10000
+    splice_insert_message {
+      avail_num         = 1
+      avails_expected   = 2
+      splice_event_id   = 3
+      unique_program_id = 4
+    }
+  }
+  ad_breaks {
+    message_type  = "TIME_SIGNAL"
+    offset_millis = 20000
+    time_signal_message {
+      segmentation_descriptors {
+        segmentation_type_id = 52
+        segmentation_upid    = "0123456789abcdef"
+      }
+    }
+  }
+}
+`, sourceLocationName, vodSourceName, channelName, programName, startTime)
+}
+
+func testAccProgramConfig_MessageType(messageType string) string {
+	return fmt.Sprintf(`
+resource "awsmt_program" "test" {
+  channel_name         = "program_validation_channel"
+  name                 = "program_validation"
+  source_location_name = "program_validation_source_location"
+  vod_source_name      = "program_validation_vod_source"
+  schedule_configuration {
+    transition_type   = "RELATIVE"
+    relative_position = "AFTER_PROGRAM"
+    relative_program  = "program_validation_previous"
+  }
+  ad_breaks {
+    message_type  = "%[1]s"
+    offset_millis = 10000
+    time_signal_message {
+      segmentation_descriptors {
+        segmentation_type_id = 52
+      }
+    }
+  }
+}
+`, messageType)
+}
@@ -0,0 +1,80 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"strings"
+	"testing"
+)
+
+func testProgramConfig(schedule map[string]interface{}) map[string]interface{} {
+	config := map[string]interface{}{
+		"channel_name":         "test",
+		"name":                 "test",
+		"source_location_name": "test",
+		"vod_source_name":      "test",
+	}
+	if schedule != nil {
+		config["schedule_configuration"] = []interface{}{schedule}
+	}
+	return config
+}
+
+func TestResourceProgramDiff_transition(t *testing.T) {
+	relative := map[string]interface{}{"transition_type": "RELATIVE", "relative_position": "AFTER_PROGRAM", "relative_program": "previous"}
+	cases := map[string]struct {
+		state       map[string]interface{}
+		config      map[string]interface{}
+		requiresNew bool
+	}{
+		"imported program":  {state: nil, config: relative, requiresNew: false},
+		"relative position": {state: relative, config: map[string]interface{}{"transition_type": "RELATIVE", "relative_position": "BEFORE_PROGRAM", "relative_program": "previous"}, requiresNew: true},
+		"relative program":  {state: relative, config: map[string]interface{}{"transition_type": "RELATIVE", "relative_position": "AFTER_PROGRAM", "relative_program": "other"}, requiresNew: true},
+		"transition type":   {state: relative, config: map[string]interface{}{"transition_type": "ABSOLUTE", "relative_position": "AFTER_PROGRAM", "scheduled_start_time_millis": 1000}, requiresNew: true},
+		"duration":          {state: relative, config: map[string]interface{}{"transition_type": "RELATIVE", "relative_position": "AFTER_PROGRAM", "relative_program": "previous", "duration_millis": 1000}, requiresNew: false},
+	}
+
+	for name, c := range cases {
+		// arrange
+		r := resourceProgram()
+		d := schema.TestResourceDataRaw(t, r.Schema, testProgramConfig(c.state))
+		d.SetId("arn:aws:mediatailor:eu-central-1:000000000000:program/test/test")
+
+		// act
+		diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(testProgramConfig(c.config)), &providerMeta{})
+
+		// assert
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", name, err)
+		}
+		if diff == nil || diff.RequiresNew() != c.requiresNew {
+			t.Errorf("%s: expected the diff to require a new resource to be %v, got %v", name, c.requiresNew, diff)
+		}
+	}
+}
+
+func TestResourceProgramDiff_validateTransition(t *testing.T) {
+	cases := map[string]struct {
+		schedule map[string]interface{}
+		err      string
+	}{
+		"relative without program":    {schedule: map[string]interface{}{"transition_type": "RELATIVE", "relative_position": "AFTER_PROGRAM"}, err: "relative_program is required with the RELATIVE transition type"},
+		"absolute without start time": {schedule: map[string]interface{}{"transition_type": "ABSOLUTE", "relative_position": "AFTER_PROGRAM"}, err: "scheduled_start_time_millis is required with the ABSOLUTE transition type"},
+		"relative":                    {schedule: map[string]interface{}{"transition_type": "RELATIVE", "relative_position": "AFTER_PROGRAM", "relative_program": "previous"}},
+		"absolute":                    {schedule: map[string]interface{}{"transition_type": "ABSOLUTE", "relative_position": "AFTER_PROGRAM", "scheduled_start_time_millis": 1000}},
+	}
+
+	for name, c := range cases {
+		// act
+		_, err := resourceProgram().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(testProgramConfig(c.schedule)), &providerMeta{})
+
+		// assert
+		if c.err == "" && err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+		if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+			t.Errorf("%s: expected error %q, got %v", name, c.err, err)
+		}
+	}
+}
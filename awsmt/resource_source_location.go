@@ -6,11 +6,13 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"strings"
+	"time"
 )
 
 func resourceSourceLocation() *schema.Resource {
@@ -20,7 +22,13 @@ func resourceSourceLocation() *schema.Resource {
 		UpdateContext: resourceSourceLocationUpdate,
 		DeleteContext: resourceSourceLocationDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importSourceLocationState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
 			"access_configuration": {
@@ -45,29 +53,44 @@ func resourceSourceLocation() *schema.Resource {
 			"arn":           &computedString,
 			"creation_time": &computedString,
 			"default_segment_delivery_configuration_url": &optionalString,
-			"http_configuration_url":                     &requiredString,
-			"last_modified_time":                         &computedString,
+			// @ADR
+			// Context: Deleting a source location used to delete all its VOD and live sources, including the ones managed
+			// by other Terraform configurations or created outside Terraform.
+			// Decision: We decided to only delete the sources of the source location if force_destroy is set, and to fail
+			// the deletion with the list of the remaining sources otherwise.
+			// Consequences: The argument is not part of the SDK and is only stored in the state.
+			"force_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"http_configuration_url": &requiredString,
+			"last_modified_time":     &computedString,
+			"region":                 &optionalRegion,
 			"segment_delivery_configurations": createOptionalList(
 				map[string]*schema.Schema{
 					"base_url": &optionalString,
 					"name":     &optionalString,
 				},
 			),
-			"name": &requiredString,
-			"tags": &optionalTags,
+			"name":     &requiredString,
+			"tags":     &optionalTags,
+			"tags_all": &computedTags,
 		},
 		CustomizeDiff: customdiff.Sequence(
 			customdiff.ForceNewIfChange("name", func(ctx context.Context, old, new, meta interface{}) bool { return old.(string) != new.(string) }),
+			setTagsDiff,
 		),
 	}
 }
 
 func resourceSourceLocationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
+	client := getClient(d, meta)
 
 	var params = getCreateSourceLocationInput(d)
+	params.Tags = getTagsAll(d, meta)
 
-	sourceLocation, err := client.CreateSourceLocation(&params)
+	sourceLocation, err := client.CreateSourceLocationWithContext(ctx, &params)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while creating the source location: %v", err))
 	}
@@ -76,8 +99,11 @@ func resourceSourceLocationCreate(ctx context.Context, d *schema.ResourceData, m
 	return resourceSourceLocationRead(ctx, d, meta)
 }
 
-func resourceSourceLocationRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
+func resourceSourceLocationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	if err := setRegion(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
 
 	resourceName := d.Get("name").(string)
 	if len(resourceName) == 0 && len(d.Id()) > 0 {
@@ -88,37 +114,43 @@ func resourceSourceLocationRead(_ context.Context, d *schema.ResourceData, meta
 		arnSections := strings.Split(resourceArn.Resource, "/")
 		resourceName = arnSections[len(arnSections)-1]
 	}
-	res, err := client.DescribeSourceLocation(&mediatailor.DescribeSourceLocationInput{SourceLocationName: aws.String(resourceName)})
+	res, err := client.DescribeSourceLocationWithContext(ctx, &mediatailor.DescribeSourceLocationInput{SourceLocationName: aws.String(resourceName)})
+	if isNotFound(err) && !d.IsNewResource() {
+		tflog.Warn(ctx, "The source location was not found, removing it from the state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while retrieving the source location: %v", err))
 	}
 
-	if err = setSourceLocation(res, d); err != nil {
-		return diag.FromErr(err)
+	if diags := setSourceLocation(res, d); diags.HasError() {
+		return diags
+	}
+	if diags := setTagsAndTagsAll(d, meta, res.Tags); diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
 
 func resourceSourceLocationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
-
-	if d.HasChange("tags") {
-		oldValue, newValue := d.GetChange("tags")
+	client := getClient(d, meta)
 
+	if d.HasChanges("tags", "tags_all") {
 		resourceName := d.Get("name").(string)
-		res, err := client.DescribeSourceLocation(&mediatailor.DescribeSourceLocationInput{SourceLocationName: &resourceName})
+		res, err := client.DescribeSourceLocationWithContext(ctx, &mediatailor.DescribeSourceLocationInput{SourceLocationName: &resourceName})
 		if err != nil {
 			return diag.FromErr(err)
 		}
 
-		if err := updateTags(client, res.Arn, oldValue, newValue); err != nil {
+		if err := updateTagsAll(ctx, client, res.Arn, d, meta); err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
 	var params = getUpdateSourceLocationInput(d)
-	sourceLocation, err := client.UpdateSourceLocation(&params)
+	sourceLocation, err := client.UpdateSourceLocationWithContext(ctx, &params)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while updating the source location: %v", err))
 	}
@@ -127,18 +159,22 @@ func resourceSourceLocationUpdate(ctx context.Context, d *schema.ResourceData, m
 	return resourceSourceLocationRead(ctx, d, meta)
 }
 
-func resourceSourceLocationDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
+func resourceSourceLocationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
 	sourceLocationName := aws.String(d.Get("name").(string))
 
-	if err := deleteVodSources(sourceLocationName, client); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := deleteLiveSources(sourceLocationName, client); err != nil {
+	if d.Get("force_destroy").(bool) {
+		if err := deleteVodSources(ctx, sourceLocationName, client); err != nil {
+			return diag.FromErr(fmt.Errorf("error while deleting the VOD sources: %v", err))
+		}
+		if err := deleteLiveSources(ctx, sourceLocationName, client); err != nil {
+			return diag.FromErr(fmt.Errorf("error while deleting the live sources: %v", err))
+		}
+	} else if err := checkSourceLocationIsEmpty(ctx, sourceLocationName, client); err != nil {
 		return diag.FromErr(err)
 	}
 
-	_, err := client.DeleteSourceLocation(&mediatailor.DeleteSourceLocationInput{SourceLocationName: sourceLocationName})
+	_, err := client.DeleteSourceLocationWithContext(ctx, &mediatailor.DeleteSourceLocationInput{SourceLocationName: sourceLocationName})
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while deleting the resource: %v", err))
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/mediatailor"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
 	"strings"
 	"testing"
 )
@@ -24,7 +25,7 @@ func init() {
 			for _, n := range names {
 				_, err = conn.DeleteSourceLocation(&mediatailor.DeleteSourceLocationInput{SourceLocationName: &n})
 				if err != nil {
-					if !strings.Contains(err.Error(), "NotFound") {
+					if !isNotFound(err) {
 						return err
 					}
 				}
@@ -146,8 +147,49 @@ func TestAccSourceLocationResource_tags(t *testing.T) {
 	})
 }
 
+func TestAccSourceLocationResource_forceDestroy(t *testing.T) {
+	rName := "source_location_test_force_destroy"
+	vodSourceName := "vod_source_test_force_destroy"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckSourceLocationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourceLocationConfig_forceDestroy(rName, false),
+				Check:  testAccCreateVodSourceOutsideTerraform(rName, vodSourceName),
+			},
+			{
+				Config:      testAccSourceLocationConfig_forceDestroy(rName, false),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta(fmt.Sprintf("still contains sources (VOD sources: %s)", vodSourceName))),
+			},
+			{
+				Config: testAccSourceLocationConfig_forceDestroy(rName, true),
+				Check:  resource.TestCheckResourceAttr("awsmt_source_location.test", "force_destroy", "true"),
+			},
+		},
+	})
+}
+
+// testAccCreateVodSourceOutsideTerraform creates a VOD source in the source location, without declaring it in the
+// configuration.
+func testAccCreateVodSourceOutsideTerraform(sourceLocationName, vodSourceName string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		conn := testAccProvider.Meta().(*providerMeta).client
+		_, err := conn.CreateVodSource(&mediatailor.CreateVodSourceInput{
+			SourceLocationName: aws.String(sourceLocationName),
+			VodSourceName:      aws.String(vodSourceName),
+			HttpPackageConfigurations: []*mediatailor.HttpPackageConfiguration{
+				{Path: aws.String("/"), SourceGroup: aws.String("default"), Type: aws.String("HLS")},
+			},
+		})
+		return err
+	}
+}
+
 func testAccCheckSourceLocationDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*mediatailor.MediaTailor)
+	conn := testAccProvider.Meta().(*providerMeta).client
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "awsmt_source_location" {
@@ -170,7 +212,7 @@ func testAccCheckSourceLocationDestroy(s *terraform.State) error {
 		input := &mediatailor.DescribeSourceLocationInput{SourceLocationName: aws.String(resourceName)}
 		_, err := conn.DescribeSourceLocation(input)
 
-		if err != nil && strings.Contains(err.Error(), "NotFound") {
+		if err != nil && isNotFound(err) {
 			continue
 		}
 
@@ -190,6 +232,16 @@ resource "awsmt_source_location" "test"{
 `, rName)
 }
 
+func testAccSourceLocationConfig_forceDestroy(rName string, forceDestroy bool) string {
+	return fmt.Sprintf(`
+resource "awsmt_source_location" "test"{
+  name = "%[1]s"
+  force_destroy = %[2]t
+  http_configuration_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/test-img.jpeg"
+}
+`, rName, forceDestroy)
+}
+
 func testAccSourceLocationConfig_update(rName, exampleString, exampleUrl, baseUrl string) string {
 	return fmt.Sprintf(`
 resource "awsmt_source_location" "test_update"{
@@ -0,0 +1,83 @@
+package awsmt
+
+import (
+	"context"
+	"errors"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResourceSourceLocationDelete(t *testing.T) {
+	cases := map[string]struct {
+		forceDestroy bool
+		vodSources   []string
+		liveSources  []string
+		pageSize     int
+		errors       map[string]error
+		expectError  string
+		expected     []string
+	}{
+		"empty source location": {
+			expected: []string{"ListVodSources", "ListLiveSources", "DeleteSourceLocation"},
+		},
+		"source location with sources": {
+			vodSources:  []string{"vod_1", "vod_2"},
+			liveSources: []string{"live_1"},
+			expectError: "the source location test still contains sources (VOD sources: vod_1, vod_2; live sources: live_1), delete them or set force_destroy to true",
+			expected:    []string{"ListVodSources", "ListLiveSources"},
+		},
+		"source location with live sources": {
+			liveSources: []string{"live_1"},
+			expectError: "the source location test still contains sources (live sources: live_1)",
+			expected:    []string{"ListVodSources", "ListLiveSources"},
+		},
+		"forced destruction": {
+			forceDestroy: true,
+			vodSources:   []string{"vod_1", "vod_2"},
+			liveSources:  []string{"live_1"},
+			expected:     []string{"ListVodSources", "DeleteVodSource vod_1", "DeleteVodSource vod_2", "ListLiveSources", "DeleteLiveSource live_1", "DeleteSourceLocation"},
+		},
+		"forced destruction with several pages": {
+			forceDestroy: true,
+			vodSources:   []string{"vod_1", "vod_2", "vod_3"},
+			liveSources:  []string{"live_1", "live_2"},
+			pageSize:     2,
+			expected:     []string{"ListVodSources", "ListVodSources", "DeleteVodSource vod_1", "DeleteVodSource vod_2", "DeleteVodSource vod_3", "ListLiveSources", "DeleteLiveSource live_1", "DeleteLiveSource live_2", "DeleteSourceLocation"},
+		},
+		"failed source deletion": {
+			forceDestroy: true,
+			vodSources:   []string{"vod_1", "vod_2"},
+			errors:       map[string]error{"DeleteVodSource vod_1": errors.New("error")},
+			expectError:  "error while deleting the VOD sources",
+			expected:     []string{"ListVodSources", "DeleteVodSource vod_1"},
+		},
+	}
+
+	for name, c := range cases {
+		// arrange
+		client := newMockMediaTailor("")
+		client.vodSources = c.vodSources
+		client.liveSources = c.liveSources
+		client.pageSize = c.pageSize
+		for k, v := range c.errors {
+			client.errors[k] = v
+		}
+		d := schema.TestResourceDataRaw(t, resourceSourceLocation().Schema, map[string]interface{}{"name": "test", "http_configuration_url": "https://example.com", "force_destroy": c.forceDestroy})
+
+		// act
+		diags := resourceSourceLocationDelete(context.Background(), d, &providerMeta{client: client})
+
+		// assert
+		if diags.HasError() != (c.expectError != "") {
+			t.Errorf("%s: unexpected diagnostics %v", name, diags)
+		}
+		if diags.HasError() && !strings.Contains(diags[0].Summary, c.expectError) {
+			t.Errorf("%s: expected the error %q, got %q", name, c.expectError, diags[0].Summary)
+		}
+		if !reflect.DeepEqual(client.calls, c.expected) {
+			t.Errorf("%s: expected the calls %v, got %v", name, c.expected, client.calls)
+		}
+	}
+}
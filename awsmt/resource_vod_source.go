@@ -6,11 +6,13 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"strings"
+	"time"
 )
 
 func resourceVodSource() *schema.Resource {
@@ -20,7 +22,13 @@ func resourceVodSource() *schema.Resource {
 		UpdateContext: resourceVodSourceUpdate,
 		DeleteContext: resourceVodSourceDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateWithRegion,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
 			"arn":           &computedString,
@@ -37,22 +45,26 @@ func resourceVodSource() *schema.Resource {
 				},
 			),
 			"last_modified_time":   &computedString,
+			"region":               &optionalRegion,
 			"source_location_name": &requiredString,
 			"tags":                 &optionalTags,
+			"tags_all":             &computedTags,
 			"name":                 &requiredString,
 		},
 		CustomizeDiff: customdiff.Sequence(
 			customdiff.ForceNewIfChange("name", func(ctx context.Context, old, new, meta interface{}) bool { return old.(string) != new.(string) }),
 			customdiff.ForceNewIfChange("source_location_name", func(ctx context.Context, old, new, meta interface{}) bool { return old.(string) != new.(string) }),
+			setTagsDiff,
 		),
 	}
 }
 
 func resourceVodSourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
+	client := getClient(d, meta)
 
 	params := getCreateVodSourceInput(d)
-	vodSource, err := client.CreateVodSource(&params)
+	params.Tags = getTagsAll(d, meta)
+	vodSource, err := client.CreateVodSourceWithContext(ctx, &params)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while creating the vod source: %v", err))
 	}
@@ -61,8 +73,11 @@ func resourceVodSourceCreate(ctx context.Context, d *schema.ResourceData, meta i
 	return resourceVodSourceRead(ctx, d, meta)
 }
 
-func resourceVodSourceRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
+func resourceVodSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
+	if err := setRegion(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
 	resourceName := d.Get("name").(string)
 	sourceLocationName := d.Get("source_location_name").(string)
 
@@ -78,38 +93,44 @@ func resourceVodSourceRead(_ context.Context, d *schema.ResourceData, meta inter
 
 	input := &mediatailor.DescribeVodSourceInput{SourceLocationName: &(sourceLocationName), VodSourceName: aws.String(resourceName)}
 
-	res, err := client.DescribeVodSource(input)
+	res, err := client.DescribeVodSourceWithContext(ctx, input)
+	if isNotFound(err) && !d.IsNewResource() {
+		tflog.Warn(ctx, "The VOD source was not found, removing it from the state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while reading the vod source: %v", err))
 	}
 
-	if err = setVodSource(res, d); err != nil {
-		return diag.FromErr(err)
+	if diags := setVodSource(res, d); diags.HasError() {
+		return diags
+	}
+	if diags := setTagsAndTagsAll(d, meta, res.Tags); diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
 
 func resourceVodSourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
-
-	if d.HasChange("tags") {
-		oldValue, newValue := d.GetChange("tags")
+	client := getClient(d, meta)
 
+	if d.HasChanges("tags", "tags_all") {
 		resourceName := d.Get("name").(string)
 		sourceLocationName := d.Get("source_location_name").(string)
-		res, err := client.DescribeVodSource(&mediatailor.DescribeVodSourceInput{SourceLocationName: &sourceLocationName, VodSourceName: &resourceName})
+		res, err := client.DescribeVodSourceWithContext(ctx, &mediatailor.DescribeVodSourceInput{SourceLocationName: &sourceLocationName, VodSourceName: &resourceName})
 		if err != nil {
 			return diag.FromErr(err)
 		}
 
-		if err := updateTags(client, res.Arn, oldValue, newValue); err != nil {
+		if err := updateTagsAll(ctx, client, res.Arn, d, meta); err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
 	var params = getUpdateVodSourceInput(d)
-	vodSource, err := client.UpdateVodSource(&params)
+	vodSource, err := client.UpdateVodSourceWithContext(ctx, &params)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while updating the vod source: %v", err))
 	}
@@ -118,10 +139,10 @@ func resourceVodSourceUpdate(ctx context.Context, d *schema.ResourceData, meta i
 	return resourceVodSourceRead(ctx, d, meta)
 }
 
-func resourceVodSourceDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*mediatailor.MediaTailor)
+func resourceVodSourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := getClient(d, meta)
 
-	_, err := client.DeleteVodSource(&mediatailor.DeleteVodSourceInput{VodSourceName: aws.String(d.Get("name").(string)), SourceLocationName: aws.String(d.Get("source_location_name").(string))})
+	_, err := client.DeleteVodSourceWithContext(ctx, &mediatailor.DeleteVodSourceInput{VodSourceName: aws.String(d.Get("name").(string)), SourceLocationName: aws.String(d.Get("source_location_name").(string))})
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error while deleting the resource: %v", err))
 	}
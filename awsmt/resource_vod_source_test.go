@@ -24,13 +24,13 @@ func init() {
 			for k, v := range names {
 				_, err = conn.DeleteVodSource(&mediatailor.DeleteVodSourceInput{SourceLocationName: &k, VodSourceName: &v})
 				if err != nil {
-					if !strings.Contains(err.Error(), "NotFound") {
+					if !isNotFound(err) {
 						return err
 					}
 				}
 				_, err = conn.DeleteSourceLocation(&mediatailor.DeleteSourceLocationInput{SourceLocationName: &k})
 				if err != nil {
-					if !strings.Contains(err.Error(), "NotFound") {
+					if !isNotFound(err) {
 						return err
 					}
 				}
@@ -133,7 +133,7 @@ func TestAccVodSourceResource_tags(t *testing.T) {
 }
 
 func testAccCheckVodSourceDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*mediatailor.MediaTailor)
+	conn := testAccProvider.Meta().(*providerMeta).client
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "awsmt_vod_source" {
@@ -156,7 +156,7 @@ func testAccCheckVodSourceDestroy(s *terraform.State) error {
 		input := &mediatailor.DescribeVodSourceInput{VodSourceName: aws.String(resourceName), SourceLocationName: aws.String("vod-source-test")}
 		_, err := conn.DescribeVodSource(input)
 
-		if err != nil && strings.Contains(err.Error(), "NotFound") {
+		if err != nil && isNotFound(err) {
 			continue
 		}
 
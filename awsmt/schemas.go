@@ -1,6 +1,9 @@
 package awsmt
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
 
 var computedString = schema.Schema{
 	Type:     schema.TypeString,
@@ -52,3 +55,49 @@ var optionalTags = schema.Schema{
 		Type: schema.TypeString,
 	},
 }
+
+var computedStringList = schema.Schema{
+	Type:     schema.TypeList,
+	Computed: true,
+	Elem: &schema.Schema{
+		Type: schema.TypeString,
+	},
+}
+
+var optionalNameRegex = schema.Schema{
+	Type:         schema.TypeString,
+	Optional:     true,
+	ValidateFunc: validation.StringIsValidRegExp,
+}
+
+var requiredForceNewTimestamp = schema.Schema{
+	Type:             schema.TypeString,
+	Required:         true,
+	ForceNew:         true,
+	ValidateFunc:     validation.IsRFC3339Time,
+	DiffSuppressFunc: suppressEquivalentTimestamps,
+}
+
+var optionalForceNewTimestamp = schema.Schema{
+	Type:             schema.TypeString,
+	Optional:         true,
+	Computed:         true,
+	ForceNew:         true,
+	ValidateFunc:     validation.IsRFC3339Time,
+	DiffSuppressFunc: suppressEquivalentTimestamps,
+}
+
+var optionalRegion = schema.Schema{
+	Type:        schema.TypeString,
+	Optional:    true,
+	Computed:    true,
+	ForceNew:    true,
+	Description: "The region of the resource. Defaults to the region of the provider configuration.",
+}
+
+var optionalComputedRegion = schema.Schema{
+	Type:        schema.TypeString,
+	Optional:    true,
+	Computed:    true,
+	Description: "The region of the data source. Defaults to the region of the provider configuration.",
+}